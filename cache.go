@@ -0,0 +1,17 @@
+package result
+
+import "time"
+
+// SetCacheable marks the Result as cacheable for the time to live
+func (r *Result) SetCacheable(ttl time.Duration) {
+	on := true
+	r.Cacheable = &on
+	r.CacheTTL = &ttl
+}
+
+// NotCacheable marks the Result as not cacheable
+func (r *Result) NotCacheable() {
+	off := false
+	r.Cacheable = &off
+	r.CacheTTL = nil
+}
@@ -0,0 +1,88 @@
+package result
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCacheControl(t *testing.T) {
+	tests := []struct {
+		name   string
+		status Status
+		mark   func(r *Result)
+		header string
+		json   string
+	}{
+		{"unmarked", OK, func(r *Result) {}, "", ""},
+		{"cacheable", OK, func(r *Result) { r.SetCacheable(90 * time.Second) }, "max-age=90", `"cacheable":true,"cache_ttl":90`},
+		{"cacheable error", EXCEPTION, func(r *Result) { r.SetCacheable(time.Minute) }, "", `"cacheable":true,"cache_ttl":60`},
+		{"not cacheable", OK, func(r *Result) { r.NotCacheable() }, "no-store", `"cacheable":false`},
+		{"cacheable then not", OK, func(r *Result) {
+			r.SetCacheable(time.Minute)
+			r.NotCacheable()
+		}, "no-store", `"cacheable":false`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := InitResult(WithStatus(tt.status))
+			tt.mark(&r)
+			rec := httptest.NewRecorder()
+			r.WriteHTTP(rec)
+			if got := rec.Header().Get("Cache-Control"); got != tt.header {
+				t.Fatalf("got Cache-Control %q, want %q", got, tt.header)
+			}
+			var m map[string]json.RawMessage
+			if err := json.Unmarshal(rec.Body.Bytes(), &m); err != nil {
+				t.Fatal(err)
+			}
+			got := ""
+			if v, ok := m["cacheable"]; ok {
+				got = `"cacheable":` + string(v)
+			}
+			if v, ok := m["cache_ttl"]; ok {
+				got += `,"cache_ttl":` + string(v)
+			}
+			if got != tt.json {
+				t.Fatalf("got %s, want %s", got, tt.json)
+			}
+		})
+	}
+}
+
+func TestCacheTTLJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		ttl  *time.Duration
+		json string
+	}{
+		{"unset", nil, ""},
+		{"seconds", ptr(90 * time.Second), "90"},
+		{"rounded down", ptr(1500 * time.Millisecond), "1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := InitResult(WithStatus(OK))
+			r.CacheTTL = tt.ttl
+			b, err := json.Marshal(r)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var m map[string]json.RawMessage
+			if err := json.Unmarshal(b, &m); err != nil {
+				t.Fatal(err)
+			}
+			if got := string(m["cache_ttl"]); got != tt.json {
+				t.Fatalf("got cache_ttl %s, want %s", got, tt.json)
+			}
+			got := Result{}
+			if err := json.Unmarshal(b, &got); err != nil {
+				t.Fatal(err)
+			}
+			if tt.ttl == nil && got.CacheTTL != nil || tt.ttl != nil && (got.CacheTTL == nil || *got.CacheTTL != tt.ttl.Truncate(time.Second)) {
+				t.Fatalf("got %v", got.CacheTTL)
+			}
+		})
+	}
+}
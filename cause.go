@@ -0,0 +1,94 @@
+package result
+
+import l "github.com/stdutil/log"
+
+// SetCause sets the upstream Result that caused this Result.
+// The upstream Result is deep-cloned, including the nested maps and slices
+// of Meta, Tag and the attributes, and its messages are not merged, so that
+// the chain can be inspected without flattening.
+func (r *Result) SetCause(upstream Result) {
+	c := upstream.clone(map[*Result]bool{r: true})
+	r.Cause = &c
+}
+
+// RootCause walks the cause chain and returns the deepest cause.
+// It returns nil if the Result has no cause.
+func (r *Result) RootCause() *Result {
+	seen := map[*Result]bool{r: true}
+	c := r.Cause
+	for c != nil && c.Cause != nil && !seen[c.Cause] {
+		seen[c] = true
+		c = c.Cause
+	}
+	return c
+}
+
+// clone returns a deep copy of the Result. The seen results break cause cycles.
+// The functions, the time location and the produced messages of lazy notes are shared.
+func (r *Result) clone(seen map[*Result]bool) Result {
+	c := *r
+	c.Messages = append(make([]string, 0, len(r.Messages)), r.Messages...)
+	c.TaskID = clonePtr(r.TaskID)
+	c.WorkerID = clonePtr(r.WorkerID)
+	c.FocusControl = clonePtr(r.FocusControl)
+	c.Page = clonePtr(r.Page)
+	c.PageCount = clonePtr(r.PageCount)
+	c.PageSize = clonePtr(r.PageSize)
+	if r.Tag != nil {
+		tag := copyAttr(*r.Tag)
+		c.Tag = &tag
+	}
+	c.CacheTTL = clonePtr(r.CacheTTL)
+	c.Cacheable = clonePtr(r.Cacheable)
+	c.ProgressRatio = clonePtr(r.ProgressRatio)
+	c.LastInsertID = clonePtr(r.LastInsertID)
+	c.ln = l.Log{Prefix: r.ln.Prefix}
+	c.ln.Append(r.ln.Notes()...)
+	c.nmeta = append([]noteMeta(nil), r.nmeta...)
+	for i := range c.nmeta {
+		c.nmeta[i].attrs = copyAttrs(c.nmeta[i].attrs)
+	}
+	c.scratch = nil
+	c.spare = nil
+	c.errs = append([]error(nil), r.errs...)
+	c.phases = append([]Phase(nil), r.phases...)
+	c.escCounts = cloneMap(r.escCounts)
+	c.onceKeys = cloneMap(r.onceKeys)
+	c.Meta = copyAttrs(r.Meta)
+	if r.transitions != nil {
+		c.SetAllowedTransitions(r.transitions)
+	}
+	c.PayloadHashes = cloneMap(r.PayloadHashes)
+	if r.groups != nil {
+		c.groups = make(map[string][]string, len(r.groups))
+		for k, v := range r.groups {
+			c.groups[k] = append([]string(nil), v...)
+		}
+	}
+	c.Cause = nil
+	if r.Cause != nil && !seen[r.Cause] {
+		seen[r] = true
+		cc := r.Cause.clone(seen)
+		c.Cause = &cc
+	}
+	return c
+}
+
+func clonePtr[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
+
+func cloneMap[K comparable, V any](m map[K]V) map[K]V {
+	if m == nil {
+		return nil
+	}
+	c := make(map[K]V, len(m))
+	for k, v := range m {
+		c[k] = v
+	}
+	return c
+}
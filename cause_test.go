@@ -0,0 +1,121 @@
+package result
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// fullResult returns a Result with every map, slice and pointer field set
+func fullResult() Result {
+	s, n, b, f, id := "s", 1, true, 0.5, int64(7)
+	ttl := time.Second
+	var tag any = map[string]any{"n": 1}
+	r := InitResult(WithStatus(OK), WithPreallocNotes(2))
+	r.AddErrorWith(map[string]any{"row": []any{1}}, "e")
+	r.AddGroupedError("g", "grouped")
+	r.AddEscalatingWarning("k", "w")
+	r.AddInfoOnce("k", "once")
+	r.AddErr(errors.New("err"))
+	r.StartPhase("p")()
+	r.SetAllowedTransitions(map[Status][]Status{OK: {EXCEPTION}})
+	r.TaskID, r.WorkerID, r.FocusControl = &s, &s, &s
+	r.Page, r.PageCount, r.PageSize = &n, &n, &n
+	r.Tag = &tag
+	r.Meta = map[string]any{"k": map[string]int{"n": 1}}
+	r.CacheTTL, r.Cacheable = &ttl, &b
+	r.ProgressRatio, r.LastInsertID = &f, &id
+	r.NotePayload("body", nil)
+	r.msgFormatter = func(m Message) string { return m.Message }
+	r.timeLoc = time.UTC
+	r.clock = time.Now
+	r.fcFormatter = func(s string) string { return s }
+	r.initErr = errors.New("option")
+	up := InitResult()
+	r.Cause = &up
+	return r
+}
+
+func TestCloneEveryField(t *testing.T) {
+	// fields that are shared by design
+	shared := map[string]bool{
+		"msgFormatter": true,
+		"timeLoc":      true,
+		"clock":        true,
+		"fcFormatter":  true,
+		"initErr":      true,
+	}
+	r := fullResult()
+	c := r.clone(map[*Result]bool{})
+	rv, cv := reflect.ValueOf(r), reflect.ValueOf(c)
+	for i := range rv.NumField() {
+		f := rv.Type().Field(i)
+		switch f.Type.Kind() {
+		case reflect.Map, reflect.Slice, reflect.Pointer, reflect.Func, reflect.Interface:
+		default:
+			continue
+		}
+		if f.Name == "scratch" || f.Name == "spare" {
+			continue // not copied
+		}
+		if rv.Field(i).IsNil() {
+			t.Errorf("%s is not set by fullResult", f.Name)
+			continue
+		}
+		if shared[f.Name] || f.Type.Kind() == reflect.Func || f.Type.Kind() == reflect.Interface {
+			continue
+		}
+		if rv.Field(i).Pointer() == cv.Field(i).Pointer() {
+			t.Errorf("%s is shared by the clone", f.Name)
+		}
+	}
+	if &r.ln.Notes()[0] == &c.ln.Notes()[0] {
+		t.Error("notes are shared by the clone")
+	}
+}
+
+func TestSetCauseDeepCopy(t *testing.T) {
+	up := InitResult(WithStatus(EXCEPTION))
+	up.Meta = map[string]any{"k": map[string]any{"n": 1}, "ints": map[string]int{"n": 1}}
+	var tag any = []int{1}
+	up.Tag = &tag
+	up.AddErrorWith(map[string]any{"ids": []int{1}}, "failed")
+	r := InitResult(WithStatus(EXCEPTION))
+	r.SetCause(up)
+	up.Meta["k"].(map[string]any)["n"] = 2
+	up.Meta["ints"].(map[string]int)["n"] = 2
+	(*up.Tag).([]int)[0] = 2
+	up.nmeta[0].attrs["ids"].([]int)[0] = 2
+	if got := r.Cause.Meta["k"].(map[string]any)["n"]; got != 1 {
+		t.Fatalf("nested meta changed to %v", got)
+	}
+	if got := r.Cause.Meta["ints"].(map[string]int)["n"]; got != 1 {
+		t.Fatalf("nested typed meta changed to %v", got)
+	}
+	if got := (*r.Cause.Tag).([]int)[0]; got != 1 {
+		t.Fatalf("tag changed to %v", got)
+	}
+	if got := r.Cause.StructuredMessages()[0].Attrs["ids"].([]int)[0]; got != 1 {
+		t.Fatalf("attrs changed to %v", got)
+	}
+}
+
+func TestRootCause(t *testing.T) {
+	root := InitResult(WithMessage("root"))
+	mid := InitResult(WithMessage("mid"))
+	mid.SetCause(root)
+	top := InitResult(WithMessage("top"))
+	top.SetCause(mid)
+	if rc := top.RootCause(); rc == nil || rc.MessagesToString() != "root" {
+		t.Fatalf("got %v", rc)
+	}
+	if none := InitResult(); none.RootCause() != nil {
+		t.Fatal("root cause of a result without cause")
+	}
+	// a cycle
+	top.Cause.Cause = &top
+	if top.RootCause() == nil {
+		t.Fatal("no root cause in a cycle")
+	}
+}
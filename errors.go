@@ -0,0 +1,58 @@
+package result
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// ToError returns the Result as an error value.
+//
+// It returns nil when the status is OK, VALID or YES, or when the Result has no
+// messages regardless of the status. Otherwise, it returns the errors added by
+// AddErr joined by errors.Join. If there are no such errors, it returns an error
+// with the messages as its text.
+func (r *Result) ToError() error {
+	switch Status(r.Status) {
+	case OK, VALID, YES:
+		return nil
+	}
+	if len(r.Messages) == 0 && len(r.ln.Notes()) == 0 {
+		return nil
+	}
+	if len(r.errs) > 0 {
+		return errors.Join(r.errs...)
+	}
+	return errors.New(strings.TrimRight(r.MessagesToString(), "\r\n"))
+}
+
+// DrainErrors adds the errors read from the channel until it is closed or the
+// context is done, and returns itself. Nil errors are skipped. The status is set
+// to EXCEPTION if any error arrived. When the context is done first, a warning
+// is added to note that the errors were partially drained.
+func (r *Result) DrainErrors(ctx context.Context, ch <-chan error) Result {
+	got, partial := false, false
+drain:
+	for {
+		select {
+		case <-ctx.Done():
+			partial = true
+			break drain
+		case err, ok := <-ch:
+			if !ok {
+				break drain
+			}
+			if err != nil {
+				got = true
+				r.AddErr(err)
+			}
+		}
+	}
+	if partial {
+		r.AddWarning("Errors were partially drained: %s", ctx.Err())
+	}
+	if got {
+		r.Return(EXCEPTION)
+	}
+	return *r
+}
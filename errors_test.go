@@ -0,0 +1,100 @@
+package result
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestToError(t *testing.T) {
+	errA, errB := errors.New("a failed"), errors.New("b failed")
+	tests := []struct {
+		name   string
+		res    func() Result
+		want   string
+		wrapsA bool
+	}{
+		{"ok", func() Result {
+			r := InitResult(WithStatus(OK))
+			r.AddError("ignored")
+			return r
+		}, "", false},
+		{"no messages", func() Result { return InitResult() }, "", false},
+		{"messages", func() Result {
+			r := InitResult()
+			r.AddError("first")
+			r.AddWarning("second")
+			return r
+		}, "ERR: first\nWRN: second", false},
+		{"retained errors", func() Result {
+			r := InitResult()
+			r.AddErr(errA)
+			r.AddErr(errB)
+			return r
+		}, "a failed\nb failed", true},
+		{"no", func() Result {
+			r := InitResult(WithStatus(NO))
+			r.AddInfo("declined")
+			return r
+		}, "INF: declined", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := tt.res()
+			err := r.ToError()
+			if tt.want == "" {
+				if err != nil {
+					t.Fatalf("got %v", err)
+				}
+				return
+			}
+			if err == nil || strings.ReplaceAll(err.Error(), "\r", "") != tt.want {
+				t.Fatalf("got %v, want %q", err, tt.want)
+			}
+			if errors.Is(err, errA) != tt.wrapsA {
+				t.Fatalf("errors.Is = %v", !tt.wrapsA)
+			}
+		})
+	}
+}
+
+func TestDrainErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		errs   []error
+		cancel bool
+		status Status
+		want   []string
+	}{
+		{"closed empty", nil, false, OK, []string{}},
+		{"nil errors", []error{nil, nil}, false, OK, []string{}},
+		{"errors", []error{errors.New("a"), nil, errors.New("b")}, false, EXCEPTION, []string{"ERR: a", "ERR: b"}},
+		{"cancelled", []error{errors.New("a")}, true, EXCEPTION, []string{"ERR: a", "WRN: Errors were partially drained: context canceled"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ch := make(chan error)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go func() {
+				for _, err := range tt.errs {
+					ch <- err
+				}
+				if tt.cancel {
+					cancel() // the channel stays open
+					return
+				}
+				close(ch)
+			}()
+			r := InitResult(WithStatus(OK))
+			r.DrainErrors(ctx, ch)
+			if r.Status != string(tt.status) {
+				t.Fatalf("got status %s", r.Status)
+			}
+			if strings.Join(r.Messages, "|") != strings.Join(tt.want, "|") {
+				t.Fatalf("got %q, want %q", r.Messages, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package result
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// ETag returns a quoted SHA-256 hash of the marshalled data for the ETag header.
+// Equal data produce the same ETag, as struct fields are marshalled in order and
+// map keys are sorted. It returns an error if the data can not be marshalled.
+func (r *ResultAny[T]) ETag() (string, error) {
+	b, err := json.Marshal(r.Data)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return `"` + hex.EncodeToString(sum[:]) + `"`, nil
+}
+
+// WriteHTTPWithETag writes the ResultAny like WriteHTTP with an ETag header.
+// If the ETag matches the If-None-Match value, only 304 Not Modified is written.
+// As the ETag only covers the data, a Result without a success status is
+// written like WriteHTTP without an ETag, so that the error is not hidden.
+func (r *ResultAny[T]) WriteHTTPWithETag(w http.ResponseWriter, ifNoneMatch string) {
+	if Status(r.Status).Severity() != SeverityInfo {
+		r.WriteHTTP(w)
+		return
+	}
+	etag, err := r.ETag()
+	if err != nil {
+		r.WriteHTTP(w)
+		return
+	}
+	w.Header().Set("ETag", etag)
+	if etagMatch(ifNoneMatch, etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	r.WriteHTTP(w)
+}
+
+// etagMatch checks if an If-None-Match value matches the ETag
+func etagMatch(ifNoneMatch, etag string) bool {
+	for _, v := range strings.Split(ifNoneMatch, ",") {
+		v = strings.TrimSpace(v)
+		if v == "*" || strings.TrimPrefix(v, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,76 @@
+package result
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWriteHTTPWithETag(t *testing.T) {
+	r := ResultAny[map[string]int]{Result: InitResult(WithStatus(OK)), Data: map[string]int{"b": 2, "a": 1}}
+	etag, err := r.ETag()
+	if err != nil {
+		t.Fatal(err)
+	}
+	same := ResultAny[map[string]int]{Result: InitResult(WithStatus(INVALID)), Data: map[string]int{"a": 1, "b": 2}}
+	if got, _ := same.ETag(); got != etag {
+		t.Fatalf("equal data gave %s and %s", got, etag)
+	}
+	tests := []struct {
+		name        string
+		ifNoneMatch string
+		code        int
+	}{
+		{"no header", "", http.StatusOK},
+		{"match", etag, http.StatusNotModified},
+		{"weak match", "W/" + etag, http.StatusNotModified},
+		{"match in list", `"other", ` + etag, http.StatusNotModified},
+		{"any", "*", http.StatusNotModified},
+		{"no match", `"other"`, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			r.WriteHTTPWithETag(rec, tt.ifNoneMatch)
+			if rec.Code != tt.code {
+				t.Fatalf("got code %d, want %d", rec.Code, tt.code)
+			}
+			if rec.Header().Get("ETag") != etag {
+				t.Fatalf("got ETag %q", rec.Header().Get("ETag"))
+			}
+			if tt.code == http.StatusNotModified && rec.Body.Len() != 0 {
+				t.Fatalf("got body %s", rec.Body)
+			}
+		})
+	}
+}
+
+func TestETagUnmarshalableData(t *testing.T) {
+	r := ResultAny[func()]{Result: InitResult(WithStatus(OK)), Data: func() {}}
+	if _, err := r.ETag(); err == nil {
+		t.Fatal("got no error")
+	}
+	rec := httptest.NewRecorder()
+	r.WriteHTTPWithETag(rec, "*")
+	if rec.Header().Get("ETag") != "" || rec.Code == http.StatusNotModified {
+		t.Fatalf("got code %d, ETag %q", rec.Code, rec.Header().Get("ETag"))
+	}
+}
+
+func TestWriteHTTPWithETagError(t *testing.T) {
+	r := ResultAny[[]int]{Result: InitResult(WithStatus(INVALID)), Data: []int{1}}
+	r.AddError("bad")
+	etag, err := r.ETag()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec := httptest.NewRecorder()
+	r.WriteHTTPWithETag(rec, etag)
+	if rec.Code != http.StatusBadRequest || rec.Header().Get("ETag") != "" {
+		t.Fatalf("got code %d, ETag %q", rec.Code, rec.Header().Get("ETag"))
+	}
+	if !strings.Contains(rec.Body.String(), "ERR: bad") {
+		t.Fatalf("got body %s", rec.Body)
+	}
+}
@@ -0,0 +1,52 @@
+package result
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// ToURLValues encodes the Result as url.Values for form-style callbacks.
+// The keys are the same as the JSON keys. Messages are encoded as repeated
+// messages keys, and nil pointer fields are omitted.
+func (r *Result) ToURLValues() url.Values {
+	r.resolveLazy()
+	v := url.Values{}
+	v.Set("status", r.Status)
+	if r.Operation != "" {
+		v.Set("operation", r.Operation)
+	}
+	if r.TaskID != nil {
+		v.Set("task_id", *r.TaskID)
+	}
+	if r.WorkerID != nil {
+		v.Set("worker_id", *r.WorkerID)
+	}
+	if r.FocusControl != nil {
+		v.Set("focus_control", *r.FocusControl)
+	}
+	if r.Page != nil {
+		v.Set("page", strconv.Itoa(*r.Page))
+	}
+	if r.PageCount != nil {
+		v.Set("page_count", strconv.Itoa(*r.PageCount))
+	}
+	if r.PageSize != nil {
+		v.Set("page_size", strconv.Itoa(*r.PageSize))
+	}
+	if r.Prefix != "" {
+		v.Set("prefix", r.Prefix)
+	}
+	for _, m := range r.Messages {
+		v.Add("messages", m)
+	}
+	return v
+}
+
+// WriteForm writes the Result as an application/x-www-form-urlencoded body
+// with the mapped HTTP status code
+func (r *Result) WriteForm(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "application/x-www-form-urlencoded")
+	w.WriteHeader(r.HTTPStatusCode())
+	w.Write([]byte(r.ToURLValues().Encode()))
+}
@@ -0,0 +1,71 @@
+package result
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestToURLValues(t *testing.T) {
+	task, fc := "t1", "email"
+	page, count, size := 2, 5, 10
+	tests := []struct {
+		name string
+		res  func() Result
+		want url.Values
+	}{
+		{"minimal", func() Result {
+			r := InitResult(WithStatus(OK), WithPrefix(""))
+			r.Operation = ""
+			return r
+		}, url.Values{"status": {"OK"}, "focus_control": {""}}},
+		{"full", func() Result {
+			r := InitResult(WithStatus(INVALID), WithPrefix("svc"))
+			r.Operation = "save"
+			r.TaskID, r.FocusControl = &task, &fc
+			r.Page, r.PageCount, r.PageSize = &page, &count, &size
+			r.AddError("a")
+			r.AddWarning("b")
+			return r
+		}, url.Values{
+			"status":        {"INVALID"},
+			"operation":     {"save"},
+			"task_id":       {"t1"},
+			"focus_control": {"email"},
+			"page":          {"2"},
+			"page_count":    {"5"},
+			"page_size":     {"10"},
+			"prefix":        {"svc"},
+			"messages":      {"ERR[svc]: a", "WRN[svc]: b"},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := tt.res()
+			if got := r.ToURLValues(); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteForm(t *testing.T) {
+	r := NotFound("user", WithPrefix(""))
+	rec := httptest.NewRecorder()
+	r.WriteForm(rec)
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("got code %d", rec.Code)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/x-www-form-urlencoded" {
+		t.Fatalf("got content type %q", ct)
+	}
+	v, err := url.ParseQuery(rec.Body.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Get("status") != "INVALID" || v.Get("messages") != "ERR: user not found" {
+		t.Fatalf("got %v", v)
+	}
+}
@@ -0,0 +1,129 @@
+package result
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+)
+
+// FromGenericResponse creates a Result from a map-based response of an older format.
+// The known keys (status, messages, operation, task_id, worker_id, focus_control,
+// prefix, tag, page, page_count and page_size) are checked for their types, and
+// the other keys are put in Meta. The notes are rebuilt from the messages.
+func FromGenericResponse(m map[string]any) (Result, error) {
+	res := Result{}
+	res.init(2)
+	res.Operation = ""
+	res.eventVerb = ""
+	for k, v := range m {
+		var err error
+		switch k {
+		case "status":
+			var s string
+			if s, err = toString(k, v); err == nil {
+				res.Status = s
+			}
+		case "operation":
+			res.Operation, err = toString(k, v)
+		case "prefix":
+			var s string
+			if s, err = toString(k, v); err == nil {
+				res.SetPrefix(s)
+			}
+		case "task_id":
+			res.TaskID, err = toStringPtr(k, v)
+		case "worker_id":
+			res.WorkerID, err = toStringPtr(k, v)
+		case "focus_control":
+			res.FocusControl, err = toStringPtr(k, v)
+		case "page":
+			res.Page, err = toIntPtr(k, v)
+		case "page_count":
+			res.PageCount, err = toIntPtr(k, v)
+		case "page_size":
+			res.PageSize, err = toIntPtr(k, v)
+		case "tag":
+			res.Tag = &v
+		case "messages":
+			res.Messages, err = toStrings(k, v)
+		default:
+			if res.Meta == nil {
+				res.Meta = make(map[string]any)
+			}
+			res.Meta[k] = v
+		}
+		if err != nil {
+			return Result{}, err
+		}
+	}
+	res.eventVerb = res.Operation
+	res.rebuildNotes()
+	return res, nil
+}
+
+func toString(key string, v any) (string, error) {
+	s, ok := v.(string)
+	if !ok {
+		return "", fmt.Errorf("%s: expected string, got %T", key, v)
+	}
+	return s, nil
+}
+
+func toStringPtr(key string, v any) (*string, error) {
+	if v == nil {
+		return nil, nil
+	}
+	s, err := toString(key, v)
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func toIntPtr(key string, v any) (*int, error) {
+	var n int
+	switch t := v.(type) {
+	case nil:
+		return nil, nil
+	case int:
+		n = t
+	case int32:
+		n = int(t)
+	case int64:
+		n = int(t)
+	case float64:
+		if t != math.Trunc(t) {
+			return nil, fmt.Errorf("%s: expected integer, got %v", key, t)
+		}
+		n = int(t)
+	case json.Number:
+		i, err := t.Int64()
+		if err != nil {
+			return nil, fmt.Errorf("%s: expected integer, got %s", key, t)
+		}
+		n = int(i)
+	default:
+		return nil, fmt.Errorf("%s: expected integer, got %T", key, v)
+	}
+	return &n, nil
+}
+
+func toStrings(key string, v any) ([]string, error) {
+	switch t := v.(type) {
+	case nil:
+		return make([]string, 0), nil
+	case []string:
+		return append(make([]string, 0, len(t)), t...), nil
+	case []any:
+		ss := make([]string, 0, len(t))
+		for i, e := range t {
+			s, ok := e.(string)
+			if !ok {
+				return nil, fmt.Errorf("%s[%d]: expected string, got %T", key, i, e)
+			}
+			ss = append(ss, s)
+		}
+		return ss, nil
+	}
+	return nil, fmt.Errorf("%s: expected array of strings, got %T", key, v)
+}
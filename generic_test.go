@@ -0,0 +1,68 @@
+package result
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestFromGenericResponse(t *testing.T) {
+	m := map[string]any{
+		"status":        "INVALID",
+		"messages":      []any{"ERR[svc]: bad input", "plain"},
+		"operation":     "save",
+		"prefix":        "svc",
+		"task_id":       "t1",
+		"worker_id":     nil,
+		"focus_control": "email",
+		"page":          float64(2),
+		"page_count":    json.Number("5"),
+		"page_size":     int64(10),
+		"tag":           "x",
+		"extra":         true,
+	}
+	r, err := FromGenericResponse(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Status != "INVALID" || r.Operation != "save" || r.Prefix != "svc" || *r.TaskID != "t1" ||
+		r.WorkerID != nil || *r.FocusControl != "email" || *r.Page != 2 || *r.PageCount != 5 ||
+		*r.PageSize != 10 || *r.Tag != "x" {
+		t.Fatalf("got %+v", r)
+	}
+	if !reflect.DeepEqual(r.Meta, map[string]any{"extra": true}) {
+		t.Fatalf("got meta %v", r.Meta)
+	}
+	errs, _, infos := r.Partition()
+	if len(errs) != 1 || errs[0].Message != "bad input" || errs[0].Prefix != "svc" || len(infos) != 1 {
+		t.Fatalf("got errors %v, infos %v", errs, infos)
+	}
+	r.AddError("more")
+	if want := []string{"ERR[svc]: bad input", "plain", "ERR[svc]: more"}; !reflect.DeepEqual(r.Messages, want) {
+		t.Fatalf("got %q, want %q", r.Messages, want)
+	}
+}
+
+func TestFromGenericResponseErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		m    map[string]any
+		want string
+	}{
+		{"status", map[string]any{"status": 1}, "status: expected string, got int"},
+		{"task id", map[string]any{"task_id": 1}, "task_id: expected string, got int"},
+		{"page fraction", map[string]any{"page": 1.5}, "page: expected integer, got 1.5"},
+		{"page type", map[string]any{"page": "1"}, "page: expected integer, got string"},
+		{"page number", map[string]any{"page_size": json.Number("1e3")}, "page_size: expected integer, got 1e3"},
+		{"messages", map[string]any{"messages": "one"}, "messages: expected array of strings, got string"},
+		{"message", map[string]any{"messages": []any{"a", 2}}, "messages[1]: expected string, got int"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := FromGenericResponse(tt.m)
+			if err == nil || err.Error() != tt.want {
+				t.Fatalf("got %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
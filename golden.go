@@ -0,0 +1,63 @@
+package result
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Golden returns a canonical representation of the Result for golden files.
+// It is indented JSON with fields in struct order, sorted map keys and
+// line feeds as line endings, terminated by a line feed.
+func (r *Result) Golden() []byte {
+	c := r.clone(map[*Result]bool{})
+	c.osIsWin = false // detail separators
+	c.resolveLazy()
+	normalizeLineEndings(&c)
+	b, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return []byte(fmt.Sprintf("error: %s\n", err))
+	}
+	return append(b, '\n')
+}
+
+// CompareGolden compares the bytes with the contents of the golden file.
+// It returns an error describing the first different line if they do not match.
+func CompareGolden(got []byte, goldenPath string) error {
+	want, err := os.ReadFile(goldenPath)
+	if err != nil {
+		return err
+	}
+	got = bytes.ReplaceAll(got, []byte("\r\n"), []byte("\n"))
+	want = bytes.ReplaceAll(want, []byte("\r\n"), []byte("\n"))
+	if bytes.Equal(got, want) {
+		return nil
+	}
+	gl := strings.Split(string(got), "\n")
+	wl := strings.Split(string(want), "\n")
+	for i := 0; i < len(gl) || i < len(wl); i++ {
+		var g, w string
+		if i < len(gl) {
+			g = gl[i]
+		}
+		if i < len(wl) {
+			w = wl[i]
+		}
+		if g != w {
+			return fmt.Errorf("%s: mismatch at line %d\n got: %s\nwant: %s", goldenPath, i+1, g, w)
+		}
+	}
+	return fmt.Errorf("%s: mismatch", goldenPath)
+}
+
+// normalizeLineEndings replaces carriage return line feeds in the messages
+func normalizeLineEndings(r *Result) {
+	for i, m := range r.Messages {
+		r.Messages[i] = strings.ReplaceAll(m, "\r\n", "\n")
+	}
+	if r.Cause != nil {
+		normalizeLineEndings(r.Cause)
+	}
+}
@@ -0,0 +1,74 @@
+package result
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func goldenResult() Result {
+	r := InitResult(WithStatus(INVALID), WithPrefix(""))
+	r.Operation = "save"
+	r.Meta = map[string]any{"b": 2, "a": 1}
+	r.AddError("line one\r\nline two")
+	r.AddWarningLazy(func() string { return "lazy\r\nwarning" })
+	return r
+}
+
+func TestGolden(t *testing.T) {
+	r := goldenResult()
+	got := string(r.Golden())
+	want := `{
+  "messages": [
+    "ERR: line one\nline two",
+    "WRN: lazy\nwarning"
+  ],`
+	if !strings.HasPrefix(got, want) || !strings.HasSuffix(got, "}\n") {
+		t.Fatalf("got %s", got)
+	}
+	if strings.Index(got, `"a": 1`) > strings.Index(got, `"b": 2`) {
+		t.Fatalf("map keys not sorted: %s", got)
+	}
+	if again := goldenResult(); string(again.Golden()) != got {
+		t.Fatal("golden output is not stable")
+	}
+	if r.Messages[0] != "ERR: line one\r\nline two" {
+		t.Fatalf("result changed to %q", r.Messages[0])
+	}
+}
+
+func TestCompareGolden(t *testing.T) {
+	dir := t.TempDir()
+	r := goldenResult()
+	got := r.Golden()
+	path := filepath.Join(dir, "result.golden")
+	if err := os.WriteFile(path, []byte(strings.ReplaceAll(string(got), "\n", "\r\n")), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		got  []byte
+		path string
+		want string
+	}{
+		{"match with crlf", got, path, ""},
+		{"mismatch", []byte(strings.Replace(string(got), "INVALID", "OK", 1)), path, "mismatch at line"},
+		{"longer", append(append([]byte(nil), got...), "extra\n"...), path, "mismatch at line"},
+		{"missing file", got, filepath.Join(dir, "missing.golden"), "no such file"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CompareGolden(tt.got, tt.path)
+			if tt.want == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("got %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package result
+
+import (
+	"fmt"
+
+	l "github.com/stdutil/log"
+)
+
+// AddGroupedError adds a formatted error message to a group, such as a section
+// of a form, and returns itself. When group focus is on, the first grouped error
+// sets the focus control to the group.
+func (r *Result) AddGroupedError(group, fmtMsg string, a ...any) Result {
+	msg := fmtMsg
+	if len(a) > 0 {
+		msg = fmt.Sprintf(fmtMsg, a...)
+	}
+	if r.groupFocus && len(r.groups) == 0 {
+		fc := group
+		r.FocusControl = &fc
+	}
+	if r.groups == nil {
+		r.groups = make(map[string][]string)
+	}
+	r.groups[group] = append(r.groups[group], msg)
+	r.add(l.Error, "%s", msg)
+	r.nmeta[len(r.nmeta)-1].group = group
+	r.nmeta[len(r.nmeta)-1].groupMsg = msg
+	return *r
+}
+
+// GroupedErrors returns the error messages added by AddGroupedError by group
+func (r *Result) GroupedErrors() map[string][]string {
+	g := make(map[string][]string, len(r.groups))
+	for k, v := range r.groups {
+		g[k] = append([]string(nil), v...)
+	}
+	return g
+}
@@ -0,0 +1,52 @@
+package result
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestGroupedErrors(t *testing.T) {
+	r := InitResult(WithStatus(INVALID), WithGroupFocus(true))
+	r.AddGroupedError("billing", "card declined")
+	r.AddGroupedError("shipping", "zip %s invalid", "x1")
+	r.AddGroupedError("billing", "name missing")
+	want := map[string][]string{
+		"billing":  {"card declined", "name missing"},
+		"shipping": {"zip x1 invalid"},
+	}
+	if got := r.GroupedErrors(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if *r.FocusControl != "billing" {
+		t.Fatalf("got focus control %q", *r.FocusControl)
+	}
+	if len(r.Messages) != 3 {
+		t.Fatalf("got %d messages", len(r.Messages))
+	}
+}
+
+func TestGroupedErrorsRedactAndDrain(t *testing.T) {
+	r := InitResult(WithStatus(INVALID))
+	r.AddGroupedError("contact", "bad email a@b.com")
+	c := r.clone(map[*Result]bool{})
+	r.RedactMessages(EmailPattern)
+	want := map[string][]string{"contact": {"bad email ***"}}
+	if got := r.GroupedErrors(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got := c.GroupedErrors()["contact"][0]; got != "bad email a@b.com" {
+		t.Fatalf("clone changed to %q", got)
+	}
+	b, err := json.Marshal(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reEmail := EmailPattern.Find(b); reEmail != nil {
+		t.Fatalf("email in JSON: %s", b)
+	}
+	r.DrainMessages()
+	if got := r.GroupedErrors(); len(got) != 0 {
+		t.Fatalf("got %v after drain", got)
+	}
+}
@@ -0,0 +1,190 @@
+package result
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+// HTTPStatusCode returns the HTTP status code mapped from the status.
+// INVALID maps to 400 Bad Request, EXCEPTION to 500 Internal Server Error,
+// registered statuses to their registered code, and other statuses to 200 OK.
+// The code set by the constructors such as NotFound takes precedence until
+// the status is changed.
+//
+// NO is the negative answer to a request, so it maps to 200 OK even though
+// its severity is error and ToError returns an error for it.
+func (r *Result) HTTPStatusCode() int {
+	if r.httpCode != 0 {
+		return r.httpCode
+	}
+	if si, ok := registeredStatus(Status(r.Status)); ok && si.httpCode != 0 {
+		return si.httpCode
+	}
+	switch Status(r.Status) {
+	case INVALID:
+		return http.StatusBadRequest
+	case EXCEPTION:
+		return http.StatusInternalServerError
+	}
+	return http.StatusOK
+}
+
+// NotFound creates an INVALID Result for a resource that was not found,
+// with the HTTP status code 404 Not Found
+func NotFound(resource string, opts ...InitResultOption) Result {
+	return newHTTPResult(http.StatusNotFound, resource+" not found", opts...)
+}
+
+// Unauthorized creates an INVALID Result for a request without valid credentials,
+// with the HTTP status code 401 Unauthorized
+func Unauthorized(opts ...InitResultOption) Result {
+	return newHTTPResult(http.StatusUnauthorized, "Unauthorized", opts...)
+}
+
+// Forbidden creates an INVALID Result for a request that is not allowed,
+// with the HTTP status code 403 Forbidden
+func Forbidden(opts ...InitResultOption) Result {
+	return newHTTPResult(http.StatusForbidden, "Forbidden", opts...)
+}
+
+// Conflict creates an INVALID Result for a request that conflicts with the
+// current state, with the HTTP status code 409 Conflict
+func Conflict(detail string, opts ...InitResultOption) Result {
+	msg := "Conflict"
+	if detail != "" {
+		msg += ": " + detail
+	}
+	return newHTTPResult(http.StatusConflict, msg, opts...)
+}
+
+// newHTTPResult creates an INVALID Result with the error message and the HTTP status code.
+// The operation is detected from the function that called the constructor.
+func newHTTPResult(code int, msg string, opts ...InitResultOption) Result {
+	res := Result{}
+	res.init(3, append([]InitResultOption{WithStatus(INVALID)}, opts...)...)
+	res.httpCode = code
+	res.AddError("%s", msg)
+	return res
+}
+
+// FromHTTPResponse creates a Result from the body of a response written by WriteHTTP.
+// A warning is added if the class of the HTTP status code does not match the status
+// of the Result, such as a 2xx code for an INVALID Result. Any 4xx code matches INVALID
+// and any 5xx code matches EXCEPTION, as the constructors such as NotFound set the code.
+// A body that is not a JSON result becomes an EXCEPTION Result with the body as the message.
+func FromHTTPResponse(resp *http.Response) (Result, error) {
+	if resp == nil {
+		return Result{}, errors.New("nil response")
+	}
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return Result{}, err
+	}
+	res := Result{}
+	if err := res.UnmarshalJSON(b); err != nil {
+		res.init(2, WithStatus(EXCEPTION))
+		res.AddError("%s", b)
+		return res, nil
+	}
+	if code := res.HTTPStatusCode(); code/100 != resp.StatusCode/100 {
+		res.AddWarning("HTTP status %d does not match the status %s (%d)", resp.StatusCode, res.Status, code)
+	}
+	return res, nil
+}
+
+// WriteHTTP writes the Result as JSON with the mapped HTTP status code.
+// The Cache-Control header is set if the Result is marked as cacheable or not.
+func (r *Result) WriteHTTP(w http.ResponseWriter) {
+	r.setCacheControl(w)
+	writeJSON(w, r.HTTPStatusCode(), r.MarshalJSON)
+}
+
+// WriteHTTP writes the ResultAny as JSON with the mapped HTTP status code
+func (r *ResultAny[T]) WriteHTTP(w http.ResponseWriter) {
+	r.setCacheControl(w)
+	writeJSON(w, r.HTTPStatusCode(), r.MarshalJSON)
+}
+
+// WriteBatch writes the results as a JSON array. The HTTP status code is the
+// highest code of the results in the batch, and the number of results with a
+// code of 400 or above is set in the X-Batch-Error-Count header, so that the
+// header agrees with the code. A NO result is written as 200 OK and is not
+// counted, like a single NO result written by WriteHTTP.
+// An empty batch is written as 200 OK with an empty array.
+func WriteBatch(w http.ResponseWriter, results []Result) {
+	rs := make([]*Result, len(results))
+	for i := range results {
+		rs[i] = &results[i]
+	}
+	writeBatch(w, rs, func(i int) ([]byte, error) {
+		return results[i].MarshalJSON()
+	})
+}
+
+// WriteBatchAny writes the ResultAny values as a JSON array like WriteBatch
+func WriteBatchAny[T any](w http.ResponseWriter, results []ResultAny[T]) {
+	rs := make([]*Result, len(results))
+	for i := range results {
+		rs[i] = &results[i].Result
+	}
+	writeBatch(w, rs, func(i int) ([]byte, error) {
+		return results[i].MarshalJSON()
+	})
+}
+
+// writeBatch writes the results rendered by render as a JSON array
+func writeBatch(w http.ResponseWriter, rs []*Result, render func(i int) ([]byte, error)) {
+	code, errCnt := http.StatusOK, 0
+	for _, r := range rs {
+		rc := r.HTTPStatusCode()
+		if rc >= http.StatusBadRequest {
+			errCnt++
+		}
+		code = max(code, rc)
+	}
+	w.Header().Set("X-Batch-Error-Count", strconv.Itoa(errCnt))
+	writeJSON(w, code, func() ([]byte, error) {
+		b := []byte{'['}
+		for i := range rs {
+			rb, err := render(i)
+			if err != nil {
+				return nil, err
+			}
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = append(b, rb...)
+		}
+		return append(b, ']'), nil
+	})
+}
+
+// setCacheControl sets the Cache-Control header to max-age for cacheable results with
+// a success status, and to no-store for results explicitly marked as not cacheable
+func (r *Result) setCacheControl(w http.ResponseWriter) {
+	if r.Cacheable == nil {
+		return
+	}
+	if !*r.Cacheable {
+		w.Header().Set("Cache-Control", "no-store")
+		return
+	}
+	if r.CacheTTL != nil && Status(r.Status).Severity() == SeverityInfo {
+		w.Header().Set("Cache-Control", "max-age="+strconv.FormatInt(int64(r.CacheTTL.Seconds()), 10))
+	}
+}
+
+// writeJSON writes the JSON returned by render with the HTTP status code
+func writeJSON(w http.ResponseWriter, code int, render func() ([]byte, error)) {
+	b, err := render()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(b)
+}
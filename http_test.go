@@ -0,0 +1,168 @@
+package result
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestFromHTTPResponse(t *testing.T) {
+	tests := []struct {
+		name    string
+		write   func(w http.ResponseWriter)
+		status  Status
+		warning bool
+	}{
+		{"ok", func(w http.ResponseWriter) {
+			r := InitResult(WithStatus(OK))
+			r.AddInfo("done")
+			r.WriteHTTP(w)
+		}, OK, false},
+		{"not found", func(w http.ResponseWriter) {
+			r := NotFound("user")
+			r.WriteHTTP(w)
+		}, INVALID, false},
+		{"timeout", func(w http.ResponseWriter) {
+			r := InitResult(WithStatus(OK))
+			r.AddTimeout("fetch", 0)
+			r.WriteHTTP(w)
+		}, TIMEOUT, false},
+		{"mismatch", func(w http.ResponseWriter) {
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"messages":["ERR: boom"],"status":"EXCEPTION"}`))
+		}, EXCEPTION, true},
+		{"not json", func(w http.ResponseWriter) {
+			w.WriteHeader(http.StatusBadGateway)
+			w.Write([]byte("bad gateway"))
+		}, EXCEPTION, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			tt.write(rec)
+			res, err := FromHTTPResponse(rec.Result())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res.Status != string(tt.status) {
+				t.Fatalf("got status %s", res.Status)
+			}
+			_, warns, _ := res.Partition()
+			if (len(warns) > 0) != tt.warning {
+				t.Fatalf("got warnings %v", warns)
+			}
+		})
+	}
+}
+
+func TestFromHTTPResponseNotJSON(t *testing.T) {
+	rec := httptest.NewRecorder()
+	rec.WriteHeader(http.StatusBadGateway)
+	rec.WriteString("bad gateway")
+	res, err := FromHTTPResponse(rec.Result())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(res.MessagesToString(), "bad gateway") {
+		t.Fatalf("got %q", res.MessagesToString())
+	}
+}
+
+func TestWriteBatch(t *testing.T) {
+	res := func(s Status) Result {
+		r := InitResult(WithStatus(s))
+		r.AddInfo("%s", s)
+		return r
+	}
+	tests := []struct {
+		name    string
+		results []Result
+		code    int
+		errCnt  string
+		items   int
+	}{
+		{"empty", nil, http.StatusOK, "0", 0},
+		{"all ok", []Result{res(OK), res(YES)}, http.StatusOK, "0", 2},
+		{"no is ok", []Result{res(OK), res(NO)}, http.StatusOK, "0", 2},
+		{"invalid", []Result{res(OK), res(INVALID)}, http.StatusBadRequest, "1", 2},
+		{"not found and exception", []Result{NotFound("user"), res(EXCEPTION), res(OK)}, http.StatusInternalServerError, "2", 3},
+		{"registered status", []Result{res(OK), res(TIMEOUT)}, http.StatusGatewayTimeout, "1", 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			WriteBatch(rec, tt.results)
+			if rec.Code != tt.code {
+				t.Fatalf("got code %d, want %d", rec.Code, tt.code)
+			}
+			if got := rec.Header().Get("X-Batch-Error-Count"); got != tt.errCnt {
+				t.Fatalf("got error count %s, want %s", got, tt.errCnt)
+			}
+			var items []json.RawMessage
+			if err := json.Unmarshal(rec.Body.Bytes(), &items); err != nil {
+				t.Fatalf("%v: %s", err, rec.Body)
+			}
+			if len(items) != tt.items || items == nil {
+				t.Fatalf("got %d items: %s", len(items), rec.Body)
+			}
+		})
+	}
+}
+
+func TestWriteBatchAny(t *testing.T) {
+	ok := ResultAny[int]{Result: InitResult(WithStatus(OK)), Data: 1}
+	bad := ResultAny[int]{Result: InitResult(WithStatus(INVALID))}
+	rec := httptest.NewRecorder()
+	WriteBatchAny(rec, []ResultAny[int]{ok, bad})
+	if rec.Code != http.StatusBadRequest || rec.Header().Get("X-Batch-Error-Count") != "1" {
+		t.Fatalf("got code %d, header %q", rec.Code, rec.Header().Get("X-Batch-Error-Count"))
+	}
+	if !strings.Contains(rec.Body.String(), `"data":1`) {
+		t.Fatalf("got %s", rec.Body)
+	}
+}
+
+func TestHTTPStatusCode(t *testing.T) {
+	tests := []struct {
+		name string
+		res  func() Result
+		want int
+	}{
+		{"ok", func() Result { return InitResult(WithStatus(OK)) }, http.StatusOK},
+		{"no", func() Result { return InitResult(WithStatus(NO)) }, http.StatusOK},
+		{"invalid", func() Result { return InitResult(WithStatus(INVALID)) }, http.StatusBadRequest},
+		{"exception", func() Result { return InitResult() }, http.StatusInternalServerError},
+		{"timeout", func() Result { return InitResult(WithStatus(TIMEOUT)) }, http.StatusGatewayTimeout},
+		{"not found", func() Result { return NotFound("user") }, http.StatusNotFound},
+		{"unauthorized", func() Result { return Unauthorized() }, http.StatusUnauthorized},
+		{"forbidden", func() Result { return Forbidden() }, http.StatusForbidden},
+		{"conflict", func() Result { return Conflict("") }, http.StatusConflict},
+		{"not found then ok", func() Result {
+			r := NotFound("user")
+			return r.Return(OK)
+		}, http.StatusOK},
+		{"not found then exception", func() Result {
+			r := NotFound("user")
+			return r.Return(EXCEPTION)
+		}, http.StatusInternalServerError},
+		{"not found then invalid", func() Result {
+			r := NotFound("user")
+			return r.Return(INVALID)
+		}, http.StatusNotFound},
+		{"not found then rejected transition", func() Result {
+			r := NotFound("user", WithStrictTransitions(true))
+			r.SetAllowedTransitions(map[Status][]Status{INVALID: {EXCEPTION}})
+			return r.Return(OK)
+		}, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := tt.res()
+			if got := r.HTTPStatusCode(); got != tt.want {
+				t.Fatalf("got %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package result
+
+import (
+	"testing"
+
+	l "github.com/stdutil/log"
+)
+
+func TestInitialMessage(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []InitResultOption
+		typ  l.LogType
+		want string
+	}{
+		{"info", []InitResultOption{WithStatus(OK)}, l.Info, "INF: Saved [id 5] ok"},
+		{"error", []InitResultOption{WithStatus(EXCEPTION)}, l.Error, "ERR: Saved [id 5] ok"},
+		{"raw", []InitResultOption{WithStatus(EXCEPTION), WithRawInitialMessage(true)}, l.App, "Saved [id 5] ok"},
+		{"raw without operation", []InitResultOption{WithStatus(OK), WithRawInitialMessage(true), UseOperationInMessage(true)}, l.App, "Saved [id 5] ok"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := InitResult(append([]InitResultOption{WithMessage("Saved [id 5] ok")}, tt.opts...)...)
+			msgs := r.StructuredMessages()
+			if len(msgs) != 1 || msgs[0].Type != tt.typ {
+				t.Fatalf("got %+v", msgs)
+			}
+			if got := r.MessagesToString(); got != tt.want {
+				t.Fatalf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAddRawMsgType(t *testing.T) {
+	r := InitResult()
+	r.AddRawMsg("WRN[db]timeout")
+	r.AddRawMsg("plain")
+	msgs := r.StructuredMessages()
+	if msgs[0].Type != l.Warn || msgs[0].Message != "timeout" || msgs[1].Type != l.App {
+		t.Fatalf("got %+v", msgs)
+	}
+}
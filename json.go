@@ -0,0 +1,241 @@
+package result
+
+import (
+	"bytes"
+	"encoding/json"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// MarshalJSON marshals the Result including the optional fields set by the options.
+//
+// The method is promoted to the types embedding a Result, which would drop
+// their other fields. A type embedding a Result must define its own MarshalJSON
+// and UnmarshalJSON, as ResultAny and PagedResult do.
+func (r Result) MarshalJSON() ([]byte, error) {
+	type result Result // prevents recursion
+	r.resolveLazy()
+	out := struct {
+		Messages any     `json:"messages,omitempty"`
+		Message  *string `json:"message,omitempty"`
+		result
+		Cause    json.RawMessage     `json:"cause,omitempty"`
+		Title    string              `json:"title,omitempty"`
+		Detail   string              `json:"detail,omitempty"`
+		CacheTTL *int64              `json:"cache_ttl,omitempty"`
+		Groups   map[string][]string `json:"grouped_errors,omitempty"`
+		Success  *bool               `json:"success,omitempty"`
+		EventID  string              `json:"event_id,omitempty"`
+	}{
+		Messages: r.Messages,
+		result:   result(r),
+		Groups:   r.groups,
+	}
+	if r.Cause != nil {
+		render := r.Cause.MarshalJSON
+		if r.metaOnly {
+			render = r.Cause.MarshalMeta
+		}
+		b, err := render()
+		if err != nil {
+			return nil, err
+		}
+		out.Cause = b
+	}
+	if r.CacheTTL != nil {
+		secs := int64(r.CacheTTL.Seconds())
+		out.CacheTTL = &secs
+	}
+	if out.Messages == nil {
+		out.Messages = []string{}
+	}
+	if r.scalarMsg && len(r.Messages) == 1 {
+		out.Messages = nil
+		out.Message = &r.Messages[0]
+	}
+	if r.eventVerb != "" {
+		out.EventID = r.EventID()
+	}
+	if r.successFlag {
+		ok := Status(r.Status).Severity() == SeverityInfo
+		out.Success = &ok
+	}
+	if r.titleDetail {
+		out.Title = r.Title()
+		out.Detail = r.Detail()
+	}
+	if r.metaOnly {
+		out.Messages, out.Message = nil, nil
+		out.Detail, out.Groups = "", nil
+	}
+	return json.Marshal(out)
+}
+
+// MarshalMeta marshals the Result without the messages, such as for status probes.
+// The other fields follow the same rules as MarshalJSON, and a Cause is included without
+// its messages as well. For a ResultAny, the data is excluded as well.
+func (r *Result) MarshalMeta() ([]byte, error) {
+	c := *r
+	c.metaOnly = true
+	return c.MarshalJSON()
+}
+
+// UnmarshalJSON unmarshals the Result and rebuilds the notes from the messages.
+// The event verb is restored from the event id, so that EventID still works.
+// A single message may be in a scalar message key. The success flag is ignored
+// as it is derived from the status.
+func (r *Result) UnmarshalJSON(b []byte) error {
+	type result Result // prevents recursion
+	in := struct {
+		*result
+		Message  *string             `json:"message"`
+		Cause    json.RawMessage     `json:"cause"`
+		CacheTTL *int64              `json:"cache_ttl"`
+		Groups   map[string][]string `json:"grouped_errors"`
+		EventID  string              `json:"event_id"`
+	}{
+		result: (*result)(r),
+	}
+	if err := json.Unmarshal(b, &in); err != nil {
+		return err
+	}
+	if len(r.Messages) == 0 && in.Message != nil {
+		r.Messages = []string{*in.Message}
+	}
+	if r.Messages == nil {
+		r.Messages = make([]string, 0)
+	}
+	r.Cause = nil
+	if len(in.Cause) > 0 && string(in.Cause) != "null" {
+		c := Result{}
+		if err := c.UnmarshalJSON(in.Cause); err != nil {
+			return err
+		}
+		r.Cause = &c
+	}
+	if in.CacheTTL != nil {
+		ttl := time.Duration(*in.CacheTTL) * time.Second
+		r.CacheTTL = &ttl
+	}
+	r.groups = in.Groups
+	r.osIsWin = runtime.GOOS == "windows"
+	r.eventVerb = r.Operation
+	if in.EventID != "" && r.EventID() != in.EventID {
+		r.eventVerb = verbOfEventID(in.EventID)
+	}
+	r.rebuildNotes()
+	return nil
+}
+
+// MarshalJSON marshals the ResultAny. It is required so that the
+// MarshalJSON of the embedded Result does not drop the data.
+func (r ResultAny[T]) MarshalJSON() ([]byte, error) {
+	b, err := r.Result.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return appendJSONField(b, "data", r.Data)
+}
+
+// UnmarshalJSON unmarshals the ResultAny. It is required so that the
+// UnmarshalJSON of the embedded Result does not skip the data.
+func (r *ResultAny[T]) UnmarshalJSON(b []byte) error {
+	if err := r.Result.UnmarshalJSON(b); err != nil {
+		return err
+	}
+	return json.Unmarshal(b, &struct {
+		Data *T `json:"data"`
+	}{&r.Data})
+}
+
+// appendJSONField adds a key and the marshalled value to a JSON object
+func appendJSONField(obj []byte, key string, v any) ([]byte, error) {
+	vb, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	kb, _ := json.Marshal(key)
+	obj = bytes.TrimRight(obj, " \t\r\n")
+	obj = obj[:len(obj)-1] // remove closing brace
+	if len(bytes.TrimSpace(obj)) > 1 {
+		obj = append(obj, ',')
+	}
+	obj = append(obj, kb...)
+	obj = append(obj, ':')
+	obj = append(obj, vb...)
+	return append(obj, '}'), nil
+}
+
+// MarshalEnvelope marshals the ResultAny with the data at the top level and the
+// result under a meta key, as {"data": ..., "meta": {"status": ..., ...}}.
+// MarshalJSON still produces the flat shape.
+func (r ResultAny[T]) MarshalEnvelope() ([]byte, error) {
+	meta, err := r.Result.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(struct {
+		Data T               `json:"data"`
+		Meta json.RawMessage `json:"meta"`
+	}{
+		Data: r.Data,
+		Meta: meta,
+	})
+}
+
+// UnmarshalEnvelope unmarshals the shape produced by MarshalEnvelope
+func (r *ResultAny[T]) UnmarshalEnvelope(b []byte) error {
+	env := struct {
+		Data T               `json:"data"`
+		Meta json.RawMessage `json:"meta"`
+	}{}
+	if err := json.Unmarshal(b, &env); err != nil {
+		return err
+	}
+	res := Result{}
+	if len(env.Meta) > 0 {
+		if err := res.UnmarshalJSON(env.Meta); err != nil {
+			return err
+		}
+	}
+	r.Result = res
+	r.Data = env.Data
+	return nil
+}
+
+// MarshalJSON marshals the PagedResult. It is required so that the
+// MarshalJSON of the embedded Result does not drop the items.
+func (r PagedResult[T]) MarshalJSON() ([]byte, error) {
+	b, err := r.Result.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	if b, err = appendJSONField(b, "items", r.Items); err != nil {
+		return nil, err
+	}
+	return appendJSONField(b, "total", r.Total)
+}
+
+// UnmarshalJSON unmarshals the PagedResult. It is required so that the
+// UnmarshalJSON of the embedded Result does not skip the items.
+func (r *PagedResult[T]) UnmarshalJSON(b []byte) error {
+	if err := r.Result.UnmarshalJSON(b); err != nil {
+		return err
+	}
+	return json.Unmarshal(b, &struct {
+		Items *[]T   `json:"items"`
+		Total *int64 `json:"total"`
+	}{&r.Items, &r.Total})
+}
+
+// verbOfEventID reverses EventID to get an event verb that produces the event id
+func verbOfEventID(id string) string {
+	if v, ok := strings.CutSuffix(id, "ed"); ok && !strings.HasSuffix(v, "e") {
+		return v
+	}
+	if v, ok := strings.CutSuffix(id, "d"); ok && strings.HasSuffix(v, "e") {
+		return v
+	}
+	return ""
+}
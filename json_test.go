@@ -0,0 +1,282 @@
+package result
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMarshalJSON(t *testing.T) {
+	ttl := 90 * time.Second
+	r := InitResult(WithStatus(INVALID), WithEventVerb("save"), WithTitleDetail(true), WithSuccessFlag(true))
+	r.CacheTTL = &ttl
+	r.AddGroupedError("contact", "bad email")
+	r.AddWarningLazy(func() string { return "later" })
+	b, err := json.Marshal(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(b, &m); err != nil {
+		t.Fatal(err)
+	}
+	for key, want := range map[string]string{
+		"messages":       `["ERR: bad email","WRN: later"]`,
+		"cache_ttl":      "90",
+		"success":        "false",
+		"event_id":       `"saved"`,
+		"title":          `"Validation failed"`,
+		"detail":         `"ERR: bad email\nWRN: later"`,
+		"grouped_errors": `{"contact":["bad email"]}`,
+	} {
+		if got := string(m[key]); got != want {
+			t.Errorf("got %s %s, want %s", key, got, want)
+		}
+	}
+	got := Result{}
+	if err := json.Unmarshal([]byte(`{"message":"INF: hi"}`), &got); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"INF: hi"}; !reflect.DeepEqual(got.Messages, want) {
+		t.Fatalf("got %q, want %q", got.Messages, want)
+	}
+}
+
+func TestJSONTitleDetail(t *testing.T) {
+	tests := []struct {
+		name   string
+		status Status
+		opts   []InitResultOption
+		title  string
+	}{
+		{"ok", OK, nil, "Success"},
+		{"exception", EXCEPTION, nil, "An error occurred"},
+		{"valid", VALID, nil, "Validation passed"},
+		{"invalid", INVALID, nil, "Validation failed"},
+		{"yes", YES, nil, "Yes"},
+		{"no", NO, nil, "No"},
+		{"override", INVALID, []InitResultOption{WithTitle("Check the form")}, "Check the form"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := append([]InitResultOption{WithStatus(tt.status), WithTitleDetail(true)}, tt.opts...)
+			r := InitResult(opts...)
+			r.AddInfo("one")
+			b, err := json.Marshal(r)
+			if err != nil {
+				t.Fatal(err)
+			}
+			out := struct {
+				Title  string `json:"title"`
+				Detail string `json:"detail"`
+			}{}
+			if err := json.Unmarshal(b, &out); err != nil {
+				t.Fatal(err)
+			}
+			if out.Title != tt.title || out.Detail != "INF: one" {
+				t.Fatalf("got title %q detail %q", out.Title, out.Detail)
+			}
+		})
+	}
+}
+
+func TestJSONScalarSingleMessageRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		msgs []string
+		key  string
+	}{
+		{"zero", nil, `"messages":[]`},
+		{"one", []string{"a"}, `"message":"INF: a"`},
+		{"many", []string{"a", "b"}, `"messages":["INF: a","INF: b"]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := InitResult(WithStatus(OK), WithScalarSingleMessage(true))
+			for _, m := range tt.msgs {
+				r.AddInfo(m)
+			}
+			b, err := json.Marshal(r)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(string(b), tt.key) {
+				t.Fatalf("missing %s in %s", tt.key, b)
+			}
+			got := Result{}
+			if err := json.Unmarshal(b, &got); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got.Messages, r.Messages) {
+				t.Fatalf("got %q, want %q", got.Messages, r.Messages)
+			}
+			if len(got.MessageManager().Notes()) != len(tt.msgs) {
+				t.Fatalf("got %d notes", len(got.MessageManager().Notes()))
+			}
+		})
+	}
+}
+
+func TestJSONCauseAndEventID(t *testing.T) {
+	up := InitResult(WithStatus(EXCEPTION), WithEventVerb("fetch"))
+	up.AddError("upstream down")
+	r := InitResult(WithStatus(EXCEPTION), WithEventVerb("save"))
+	r.SetCause(up)
+	b, err := json.Marshal(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := Result{}
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.EventID() != "saved" {
+		t.Fatalf("got event id %q", got.EventID())
+	}
+	if got.Cause == nil || got.Cause.EventID() != "fetched" || got.Cause.MessagesToString() != "ERR: upstream down" {
+		t.Fatalf("got cause %+v", got.Cause)
+	}
+}
+
+func TestResultAnyJSONRoundTrip(t *testing.T) {
+	r := ResultAny[[]int]{Result: InitResult(WithStatus(OK))}
+	r.Data = []int{1, 2}
+	b, err := json.Marshal(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := ResultAny[[]int]{}
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got.Data, r.Data) || got.Status != string(OK) {
+		t.Fatalf("got %+v", got)
+	}
+}
+
+func TestJSONSuccessFlag(t *testing.T) {
+	tests := []struct {
+		status Status
+		on     bool
+		want   string
+	}{
+		{OK, true, "true"},
+		{VALID, true, "true"},
+		{YES, true, "true"},
+		{NO, true, "false"},
+		{INVALID, true, "false"},
+		{EXCEPTION, true, "false"},
+		{OK, false, ""},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s %v", tt.status, tt.on), func(t *testing.T) {
+			r := InitResult(WithStatus(tt.status), WithSuccessFlag(tt.on))
+			b, err := json.Marshal(r)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var m map[string]json.RawMessage
+			if err := json.Unmarshal(b, &m); err != nil {
+				t.Fatal(err)
+			}
+			if got := string(m["success"]); got != tt.want {
+				t.Fatalf("got success %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarshalMeta(t *testing.T) {
+	cause := InitResult(WithStatus(EXCEPTION))
+	cause.AddError("db down")
+	r := ResultAny[[]int]{Result: InitResult(WithStatus(INVALID), WithTitleDetail(true)), Data: []int{1}}
+	r.Operation = "save"
+	r.AddGroupedError("form", "bad input")
+	r.SetCause(cause)
+	b, err := r.MarshalMeta()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(b, &m); err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"messages", "message", "detail", "grouped_errors", "data"} {
+		if v, ok := m[key]; ok {
+			t.Errorf("got %s: %s", key, v)
+		}
+	}
+	for _, key := range []string{"status", "operation", "title", "cause"} {
+		if _, ok := m[key]; !ok {
+			t.Errorf("missing %s in %s", key, b)
+		}
+	}
+	if strings.Contains(string(m["cause"]), "db down") {
+		t.Errorf("cause messages in %s", m["cause"])
+	}
+	if len(r.Messages) != 1 {
+		t.Fatalf("result changed: %q", r.Messages)
+	}
+}
+
+func TestEnvelope(t *testing.T) {
+	type item struct {
+		ID int `json:"id"`
+	}
+	tests := []struct {
+		name string
+		data []item
+		msgs []string
+	}{
+		{"with data", []item{{1}, {2}}, []string{"INF: found 2"}},
+		{"no data", nil, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := ResultAny[[]item]{Result: InitResult(WithStatus(OK)), Data: tt.data}
+			for _, m := range tt.msgs {
+				r.AddInfoText(m[len("INF: "):])
+			}
+			b, err := r.MarshalEnvelope()
+			if err != nil {
+				t.Fatal(err)
+			}
+			var shape map[string]json.RawMessage
+			if err := json.Unmarshal(b, &shape); err != nil {
+				t.Fatal(err)
+			}
+			if len(shape) != 2 || shape["data"] == nil || shape["meta"] == nil {
+				t.Fatalf("got %s", b)
+			}
+			got := ResultAny[[]item]{}
+			if err := got.UnmarshalEnvelope(b); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got.Data, tt.data) || got.Status != "OK" || !reflect.DeepEqual(got.Messages, tt.msgs) {
+				t.Fatalf("got %+v", got)
+			}
+		})
+	}
+}
+
+func TestUnmarshalEnvelopeErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+	}{
+		{"not json", "{"},
+		{"data type", `{"data":"x","meta":{}}`},
+		{"meta type", `{"data":[],"meta":{"status":1}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := ResultAny[[]int]{}
+			if err := r.UnmarshalEnvelope([]byte(tt.in)); err == nil {
+				t.Fatalf("got no error, result %+v", r)
+			}
+		})
+	}
+}
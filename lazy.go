@@ -0,0 +1,98 @@
+package result
+
+import (
+	"strings"
+	"sync"
+
+	l "github.com/stdutil/log"
+)
+
+// lazyMsg is a message produced by a function at most once. It is shared by the
+// copies of a note, such as notes appended to other results by Stuff.
+type lazyMsg struct {
+	once sync.Once
+	fn   func() string
+	msg  string
+}
+
+// AddInfoLazy adds an information message produced by fn and returns itself.
+//
+// The function is called at most once, when the messages are rendered by
+// MessagesToString, MarshalJSON, ToURLValues, StructuredMessages, Partition,
+// RedactMessages, a StreamWriter or a ResultMatcher, and only if the message is
+// not below the minimum severity set by WithMinSeverity. Until then, the message
+// is not in Messages, and the hooks get the message as empty.
+func (r *Result) AddInfoLazy(fn func() string) Result {
+	return r.addLazy(l.Info, fn)
+}
+
+// AddWarningLazy adds a warning message produced by fn and returns itself.
+// The function is called as in AddInfoLazy.
+func (r *Result) AddWarningLazy(fn func() string) Result {
+	return r.addLazy(l.Warn, fn)
+}
+
+// AddErrorLazy adds an error message produced by fn and returns itself.
+// The function is called as in AddInfoLazy.
+func (r *Result) AddErrorLazy(fn func() string) Result {
+	return r.addLazy(l.Error, fn)
+}
+
+// addLazy adds a note of the type with the message produced later by fn
+func (r *Result) addLazy(typ l.LogType, fn func() string) Result {
+	if r.failFast && r.noteSeverity(typ) < SeverityError && r.HasErrors() {
+		return *r
+	}
+	if fn == nil {
+		fn = func() string { return "" }
+	}
+	r.addNote(typ, "", &lazyMsg{fn: fn})
+	return *r
+}
+
+// get returns the message, calling the function the first time
+func (lm *lazyMsg) get() string {
+	lm.once.Do(func() {
+		lm.msg = lm.fn()
+		lm.fn = nil
+	})
+	return lm.msg
+}
+
+// isLazy checks if the note at index i has a message that is not produced yet
+func (r *Result) isLazy(i int) bool {
+	return i < len(r.nmeta) && r.nmeta[i].lazy != nil
+}
+
+// resolveLazy produces the messages of the lazy notes that are not below the
+// minimum severity, and renders the messages again if any was produced.
+// The notes and their metadata are copied before they are changed, as copies
+// of the Result returned by the Add methods share them.
+func (r *Result) resolveLazy() {
+	nts := r.ln.Notes()
+	n := 0
+	for i := range nts {
+		if !r.isLazy(i) || r.noteSeverity(nts[i].Type) < r.minSev {
+			continue
+		}
+		if n == 0 {
+			nts = append([]l.LogInfo(nil), nts...)
+			r.nmeta = append([]noteMeta(nil), r.nmeta...)
+		}
+		lm := r.nmeta[i].lazy
+		msg := strings.TrimSpace(r.operationMessage(lm.get()))
+		r.nmeta[i].lazy = nil
+		if tm, ok := truncateRunes(msg, r.maxMsgLen); ok {
+			r.nmeta[i].original = msg
+			msg = tm
+		}
+		nts[i].Message = msg
+		n++
+	}
+	if n > 0 {
+		r.ln.Clear()
+		r.ln.Append(nts...)
+		r.rendered = 0 // notes changed
+		r.updateMessage()
+	}
+}
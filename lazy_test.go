@@ -0,0 +1,137 @@
+package result
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestAddLazy(t *testing.T) {
+	tests := []struct {
+		name   string
+		opts   []InitResultOption
+		add    func(r *Result, fn func() string)
+		render func(r *Result)
+		calls  int
+		want   []string
+	}{
+		{"info rendered", nil, func(r *Result, fn func() string) { r.AddInfoLazy(fn) },
+			func(r *Result) { r.MessagesToString() }, 1, []string{"INF: lazy"}},
+		{"error by json", nil, func(r *Result, fn func() string) { r.AddErrorLazy(fn) },
+			func(r *Result) {
+				json.Marshal(r)
+				r.MessagesToString()
+			}, 1, []string{"ERR: lazy"}},
+		{"warning by structured", nil, func(r *Result, fn func() string) { r.AddWarningLazy(fn) },
+			func(r *Result) { r.StructuredMessages() }, 1, []string{"WRN: lazy"}},
+		{"filtered", []InitResultOption{WithMinSeverity(SeverityWarning)}, func(r *Result, fn func() string) { r.AddInfoLazy(fn) },
+			func(r *Result) {
+				r.MessagesToString()
+				json.Marshal(r)
+				r.StructuredMessages()
+			}, 0, []string{}},
+		{"not rendered", nil, func(r *Result, fn func() string) { r.AddInfoLazy(fn) },
+			func(r *Result) {}, 0, []string{}},
+		{"cached", nil, func(r *Result, fn func() string) { r.AddInfoLazy(fn) },
+			func(r *Result) {
+				r.MessagesToString()
+				r.MessagesToString()
+				json.Marshal(r)
+			}, 1, []string{"INF: lazy"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := 0
+			r := InitResult(tt.opts...)
+			tt.add(&r, func() string {
+				n++
+				return "lazy"
+			})
+			tt.render(&r)
+			if n != tt.calls {
+				t.Fatalf("called %d times, want %d", n, tt.calls)
+			}
+			if !reflect.DeepEqual(r.Messages, tt.want) {
+				t.Fatalf("got %q, want %q", r.Messages, tt.want)
+			}
+		})
+	}
+}
+
+func TestAddLazyOrderAndSharing(t *testing.T) {
+	n := 0
+	src := InitResult()
+	src.AddInfo("first")
+	src.AddInfoLazy(func() string {
+		n++
+		return "second"
+	})
+	src.AddInfo("third")
+	a, b := InitResult(), InitResult()
+	a.Stuff(src)
+	b.Stuff(src)
+	want := []string{"INF: first", "INF: second", "INF: third"}
+	for _, r := range []*Result{&a, &b, &src} {
+		r.MessagesToString()
+		if !reflect.DeepEqual(r.Messages, want) {
+			t.Fatalf("got %q, want %q", r.Messages, want)
+		}
+	}
+	if n != 1 {
+		t.Fatalf("called %d times", n)
+	}
+}
+
+func TestAddLazyPrealloc(t *testing.T) {
+	r := InitResult(WithPreallocNotes(4))
+	r.AddErrorLazy(func() string { return "x" })
+	r.AddInfo("y")
+	r.MessagesToString()
+	if want := []string{"ERR: x", "INF: y"}; !reflect.DeepEqual(r.Messages, want) {
+		t.Fatalf("got %q, want %q", r.Messages, want)
+	}
+}
+
+func TestAddLazyResolvedByCopy(t *testing.T) {
+	n := 0
+	r := InitResult(WithStatus(OK))
+	c := r.AddInfoLazy(func() string {
+		n++
+		return "lazy"
+	})
+	c.MessagesToString()
+	r.MessagesToString()
+	want := []string{"INF: lazy"}
+	if !reflect.DeepEqual(r.Messages, want) || !reflect.DeepEqual(c.Messages, want) {
+		t.Fatalf("got %q and copy %q, want %q", r.Messages, c.Messages, want)
+	}
+	if n != 1 {
+		t.Fatalf("called %d times", n)
+	}
+}
+
+func TestAddLazyRenderedByFormAndMatcher(t *testing.T) {
+	lazy := func() string { return "lazy" }
+	tests := []struct {
+		name   string
+		render func(r *Result) bool
+	}{
+		{"url values", func(r *Result) bool {
+			return reflect.DeepEqual(r.ToURLValues()["messages"], []string{"INF: lazy"})
+		}},
+		{"matcher", func(r *Result) bool {
+			want := InitResult(WithStatus(OK))
+			want.AddInfoLazy(lazy)
+			return ResultMatcher{Expected: want}.Matches(r) && MatchResult(want)(*r)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := InitResult(WithStatus(OK))
+			r.AddInfoLazy(lazy)
+			if !tt.render(&r) {
+				t.Fatalf("lazy message not rendered: %q", r.Messages)
+			}
+		})
+	}
+}
@@ -1,41 +1,146 @@
 package result
 
-import "github.com/stdutil/log"
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/stdutil/log"
+)
 
 type (
 	Status string
+	// Severity ranks statuses and messages from the least to the most severe
+	Severity int
 	// Result - standard result structure
 	Result struct {
-		Messages          []string     `json:"messages"`                // Accumulated messages as a result from Add methods. Do not append messages using append()
-		Status            string       `json:"status"`                  // OK, ERROR, VALID or any status
-		Operation         string       `json:"operation,omitempty"`     // Name of the operation / function that returned the result
-		TaskID            *string      `json:"task_id,omitempty"`       // ID of the task and of the result
-		WorkerID          *string      `json:"worker_id,omitempty"`     // ID of the worker that processed the data
-		FocusControl      *string      `json:"focus_control,omitempty"` // Control to focus when error was activated
-		Page              *int         `json:"page,omitempty"`          // Current Page
-		PageCount         *int         `json:"page_count,omitempty"`    // Page Count
-		PageSize          *int         `json:"page_size,omitempty"`     // Page Size
-		Tag               *interface{} `json:"tag,omitempty"`           // Miscellaneous result
-		Prefix            string       `json:"prefix,omitempty"`        // Prefix of the message to return
-		ln                log.Log      // Internal note
-		eventVerb         string       // event verb related to the name of the operation
-		osIsWin           bool         // checks for OS to determine carriage return line feed
-		useOperationInMsg bool         // use Operation value in messages
-		initFc            string       // original focus control
+		Messages          []string                  `json:"messages"`                 // Accumulated messages as a result from Add methods. Do not append messages using append()
+		Status            string                    `json:"status"`                   // OK, ERROR, VALID or any status
+		Operation         string                    `json:"operation,omitempty"`      // Name of the operation / function that returned the result
+		TaskID            *string                   `json:"task_id,omitempty"`        // ID of the task and of the result
+		WorkerID          *string                   `json:"worker_id,omitempty"`      // ID of the worker that processed the data
+		FocusControl      *string                   `json:"focus_control,omitempty"`  // Control to focus when error was activated
+		Page              *int                      `json:"page,omitempty"`           // Current Page
+		PageCount         *int                      `json:"page_count,omitempty"`     // Page Count
+		PageSize          *int                      `json:"page_size,omitempty"`      // Page Size
+		Tag               *interface{}              `json:"tag,omitempty"`            // Miscellaneous result
+		Prefix            string                    `json:"prefix,omitempty"`         // Prefix of the message to return
+		Cause             *Result                   `json:"cause,omitempty"`          // Upstream result that caused this result
+		Meta              map[string]any            `json:"meta,omitempty"`           // Additional response data
+		CacheTTL          *time.Duration            `json:"cache_ttl,omitempty"`      // Time to live of a cacheable result, marshalled in seconds
+		Cacheable         *bool                     `json:"cacheable,omitempty"`      // Result can be cached
+		ProgressRatio     *float64                  `json:"progress,omitempty"`       // Progress of a long operation from 0.0 to 1.0
+		Retryable         bool                      `json:"retryable,omitempty"`      // The operation can be retried
+		LastInsertID      *int64                    `json:"last_insert_id,omitempty"` // ID of the last inserted row set by FromSQLResult
+		PayloadHashes     map[string]string         `json:"payload_hashes,omitempty"` // SHA-256 hashes of the payloads by name set by NotePayload
+		ln                log.Log                   // Internal note
+		eventVerb         string                    // event verb related to the name of the operation
+		osIsWin           bool                      // checks for OS to determine carriage return line feed
+		useOperationInMsg bool                      // use Operation value in the messages of the Add methods
+		initErr           error                     // errors of the options
+		initFc            string                    // original focus control
+		strictTmpl        bool                      // missing template keys produce an error
+		title             string                    // title override
+		titleDetail       bool                      // include title and detail in JSON
+		escThreshold      int                       // occurrences of an escalating warning before it becomes an error
+		escCounts         map[string]int            // occurrences of escalating warnings by key
+		errs              []error                   // errors added by AddErr
+		nmeta             []noteMeta                // metadata of the notes, aligned by index with the notes
+		maxMsgLen         int                       // maximum number of runes of a message
+		failFast          bool                      // skip non-error messages once an error was added
+		msgFormatter      func(note Message) string // custom rendering of a message
+		timeLoc           *time.Location            // location of the message timestamps
+		transitions       map[Status][]Status       // allowed status transitions
+		strictTrans       bool                      // block disallowed status transitions
+		onceKeys          map[string]bool           // keys used by the Add once methods
+		warnAsErr         bool                      // treat warnings as errors in severity computations
+		zeroRowsWarn      bool                      // add a warning when no rows were affected
+		zeroRowsStatus    Status                    // status to set when no rows were affected
+		scalarMsg         bool                      // marshal a single message as a scalar message key
+		minSev            Severity                  // minimum severity of the rendered messages
+		httpCode          int                       // HTTP status code override
+		dedupMerge        bool                      // skip appended notes that are already present
+		groups            map[string][]string       // error messages by group
+		groupFocus        bool                      // set the focus control to the group of the first grouped error
+		successFlag       bool                      // include a success boolean in the JSON output
+		inclErrType       bool                      // include the type of errors in messages
+		opMsgFmt          string                    // format of the operation and message
+		hooks             Hooks                     // hooks overriding the global hooks
+		metaOnly          bool                      // marshal without messages
+		prealloc          int                       // number of notes storage is reserved for
+		rendered          int                       // number of notes rendered in the messages when storage is reserved
+		scratch           []byte                    // buffer to render messages when storage is reserved
+		coerce            bool                      // CoerceStatus sets the coerced status
+		clock             func() time.Time          // current time of the notes and phases
+		phases            []Phase                   // phases started by StartPhase
+		seq               uint64                    // sequence number of the last added note
+		fcFormatter       func(field string) string // formats the focus control set by a field error
+		spare             *spare                    // storage kept for reuse when the Result is pooled
 	}
 	// ResultAny struct with generic type data
 	ResultAny[T any] struct {
 		Result
 		Data T `json:"data"`
 	}
+	// Message is the structured form of a note in the Result
+	Message struct {
+		Type      log.LogType    `json:"type"`                 // Type of the note (INF, WRN, ERR, FTL, SUC or empty for application messages)
+		Prefix    string         `json:"prefix,omitempty"`     // Prefix of the note
+		Message   string         `json:"message"`              // Message of the note
+		Time      *time.Time     `json:"time,omitempty"`       // Time the note was added
+		ErrorType string         `json:"error_type,omitempty"` // Type of the error added by AddErr
+		Step      string         `json:"step,omitempty"`       // Pipeline step that added the note
+		Field     string         `json:"field,omitempty"`      // Field of a field error
+		Attrs     map[string]any `json:"attrs,omitempty"`      // Attributes of the note
+		Operation string         `json:"operation,omitempty"`  // Operation of the Result the note was added to
+		Seq       uint64         `json:"seq,omitempty"`        // Sequence number of the note in the Result, increasing with each added note
+	}
+	// PagedResult struct with a page of generic typed items
+	PagedResult[T any] struct {
+		Result
+		Items []T   `json:"items"`
+		Total int64 `json:"total"` // Total number of items in all pages
+	}
+	// Hooks are functions called around the addition of each message.
+	// The message is a copy, and the hooks must not add messages to the Result.
+	Hooks struct {
+		BeforeAdd func(r *Result, m Message) // Called before the message is added
+		AfterAdd  func(r *Result, m Message) // Called after the message is added
+	}
 	// InitResultParam are optional parameters for initiating a Result
 	InitResultParam struct {
-		EventVerb         string // Custom event verb or id
-		Status            Status // Initial status
-		Prefix            string // Prefix
-		Message           string // Message
-		InitialFocusID    string // Initial Focus Control id
-		UseOperationInMsg bool   // Use Operation tag in messages
+		EventVerb              string                    // Custom event verb or id
+		Status                 Status                    // Initial status
+		Prefix                 string                    // Prefix
+		Message                string                    // Message
+		InitialFocusID         string                    // Initial Focus Control id
+		UseOperationInMsg      bool                      // Use Operation tag in the initial message
+		OperationInAllMessages bool                      // Use Operation tag in the messages of the Add methods
+		StrictTemplates        bool                      // Missing template keys produce an error
+		Title                  string                    // Title override
+		TitleDetail            bool                      // Include title and detail in JSON
+		EscalationThreshold    int                       // Occurrences of an escalating warning before it becomes an error
+		MaxMessageLength       int                       // Maximum number of runes of a message
+		FailFast               bool                      // Skip non-error messages once an error was added
+		MessageFormatter       func(note Message) string // Custom rendering of a message
+		TimeLocation           *time.Location            // Location of the message timestamps
+		StrictTransitions      bool                      // Block disallowed status transitions instead of warning
+		WarningsAsErrors       bool                      // Treat warnings as errors in severity computations
+		ZeroRowsAsWarning      bool                      // Add a warning when no rows were affected
+		ZeroRowsStatus         Status                    // Status to set when no rows were affected
+		ScalarSingleMessage    bool                      // Marshal a single message as a scalar message key
+		MinSeverity            Severity                  // Minimum severity of the rendered messages
+		DedupOnMerge           bool                      // Skip appended notes that are already present
+		GroupFocus             bool                      // Set the focus control to the group of the first grouped error
+		SuccessFlag            bool                      // Include a success boolean in the JSON output
+		IncludeErrorType       bool                      // Include the type of errors in messages
+		OperationMessageFormat string                    // Format of the operation and message when the operation is used in messages
+		Hooks                  Hooks                     // Hooks overriding the global hooks
+		PreallocNotes          int                       // Number of notes to reserve storage for
+		CoerceStatus           bool                      // CoerceStatus sets the coerced status
+		RawInitialMessage      bool                      // Add the initial message as an application message
+		Clock                  func() time.Time          // Current time of the notes and phases
+		FocusControlFormatter  func(field string) string // Formats the focus control set by a field error
 	}
 	// InitResultOption for initial result parameters
 	InitResultOption func(opt *InitResultParam) error
@@ -81,10 +186,250 @@ func WithEventVerb(eventVerb string) InitResultOption {
 	}
 }
 
-// UseOperationInMessage sets to include the Operation tag in messages
+// UseOperationInMessage sets to include the Operation tag in the initial message.
+// Use WithOperationInAllMessages to include it in the messages of the Add methods.
 func UseOperationInMessage(on bool) InitResultOption {
 	return func(irp *InitResultParam) error {
 		irp.UseOperationInMsg = on
 		return nil
 	}
 }
+
+// WithOperationInAllMessages sets to include the Operation tag in the messages of
+// the Add methods, formatted by WithOperationMessageFormat
+func WithOperationInAllMessages(on bool) InitResultOption {
+	return func(irp *InitResultParam) error {
+		irp.OperationInAllMessages = on
+		return nil
+	}
+}
+
+// WithStrictTemplates sets templated messages to produce an error on missing keys
+// instead of leaving the placeholder as is
+func WithStrictTemplates(on bool) InitResultOption {
+	return func(irp *InitResultParam) error {
+		irp.StrictTemplates = on
+		return nil
+	}
+}
+
+// WithTitle overrides the status-derived title of the Result
+func WithTitle(s string) InitResultOption {
+	return func(irp *InitResultParam) error {
+		irp.Title = s
+		return nil
+	}
+}
+
+// WithTitleDetail sets to include the title and detail in the JSON output
+func WithTitleDetail(on bool) InitResultOption {
+	return func(irp *InitResultParam) error {
+		irp.TitleDetail = on
+		return nil
+	}
+}
+
+// WithEscalationThreshold sets the number of occurrences of an escalating warning
+// before it is recorded as an error
+func WithEscalationThreshold(n int) InitResultOption {
+	return func(irp *InitResultParam) error {
+		irp.EscalationThreshold = n
+		return nil
+	}
+}
+
+// WithMaxMessageLength sets the maximum number of runes of a message.
+// Longer messages are truncated with an ellipsis.
+func WithMaxMessageLength(n int) InitResultOption {
+	return func(irp *InitResultParam) error {
+		irp.MaxMessageLength = n
+		return nil
+	}
+}
+
+// WithFailFast sets to skip information, warning and success messages once an
+// error message has been added. Error messages are still added.
+func WithFailFast(on bool) InitResultOption {
+	return func(irp *InitResultParam) error {
+		irp.FailFast = on
+		return nil
+	}
+}
+
+// WithMessageFormatter sets a function that renders each message of the Result,
+// replacing the default rendering of type, prefix and message
+func WithMessageFormatter(fn func(note Message) string) InitResultOption {
+	return func(irp *InitResultParam) error {
+		irp.MessageFormatter = fn
+		return nil
+	}
+}
+
+// WithTimeLocation sets the location used to render the message timestamps.
+// The default is UTC.
+func WithTimeLocation(loc *time.Location) InitResultOption {
+	return func(irp *InitResultParam) error {
+		irp.TimeLocation = loc
+		return nil
+	}
+}
+
+// WithStrictTransitions sets disallowed status transitions to be blocked with an
+// error message instead of being applied with a warning message
+func WithStrictTransitions(on bool) InitResultOption {
+	return func(irp *InitResultParam) error {
+		irp.StrictTransitions = on
+		return nil
+	}
+}
+
+// WithWarningsAsErrors sets warning messages to be treated as errors in severity
+// computations such as HasErrors and fail-fast. They are still rendered as warnings.
+func WithWarningsAsErrors(on bool) InitResultOption {
+	return func(irp *InitResultParam) error {
+		irp.WarningsAsErrors = on
+		return nil
+	}
+}
+
+// WithZeroRowsAsWarning sets RowsAffectedInfo to add a warning instead of an
+// information message when no rows were affected
+func WithZeroRowsAsWarning(on bool) InitResultOption {
+	return func(irp *InitResultParam) error {
+		irp.ZeroRowsAsWarning = on
+		return nil
+	}
+}
+
+// WithZeroRowsStatus sets the status that RowsAffectedInfo sets when no rows
+// were affected and zero rows are a warning
+func WithZeroRowsStatus(status Status) InitResultOption {
+	return func(irp *InitResultParam) error {
+		irp.ZeroRowsStatus = status
+		return nil
+	}
+}
+
+// WithScalarSingleMessage sets MarshalJSON to emit a scalar message key when
+// there is exactly one message, and the messages array otherwise
+func WithScalarSingleMessage(on bool) InitResultOption {
+	return func(irp *InitResultParam) error {
+		irp.ScalarSingleMessage = on
+		return nil
+	}
+}
+
+// WithMinSeverity sets the minimum severity of the messages rendered in Messages,
+// MessagesToString and the JSON output. StructuredMessages still returns all messages.
+func WithMinSeverity(s Severity) InitResultOption {
+	return func(irp *InitResultParam) error {
+		irp.MinSeverity = s
+		return nil
+	}
+}
+
+// WithDedupOnMerge sets Stuff and the Append methods to skip the notes of the
+// other Result that are already present, such as notes of a shared upstream Result
+func WithDedupOnMerge(on bool) InitResultOption {
+	return func(irp *InitResultParam) error {
+		irp.DedupOnMerge = on
+		return nil
+	}
+}
+
+// WithGroupFocus sets the focus control to the group of the first grouped error
+func WithGroupFocus(on bool) InitResultOption {
+	return func(irp *InitResultParam) error {
+		irp.GroupFocus = on
+		return nil
+	}
+}
+
+// WithSuccessFlag sets MarshalJSON to include a success boolean that is true
+// for the OK, VALID and YES statuses
+func WithSuccessFlag(on bool) InitResultOption {
+	return func(irp *InitResultParam) error {
+		irp.SuccessFlag = on
+		return nil
+	}
+}
+
+// WithIncludeErrorType sets AddErr and AddErrWithAlt to prepend the type of the
+// error, such as *net.OpError, to the message
+func WithIncludeErrorType(on bool) InitResultOption {
+	return func(irp *InitResultParam) error {
+		irp.IncludeErrorType = on
+		return nil
+	}
+}
+
+// WithOperationMessageFormat sets the format of messages when the Operation is
+// used in messages. The format must have two %s verbs, for the operation and
+// the message, such as "[%s] %s". The default is " %s: %s". An invalid format
+// is not applied, and the error is returned by InitError of the Result.
+func WithOperationMessageFormat(fmtStr string) InitResultOption {
+	return func(irp *InitResultParam) error {
+		f := strings.ReplaceAll(fmtStr, "%%", "")
+		if strings.Count(f, "%") != 2 || strings.Count(f, "%s") != 2 {
+			return fmt.Errorf("operation message format %q must have two %%s verbs", fmtStr)
+		}
+		irp.OperationMessageFormat = fmtStr
+		return nil
+	}
+}
+
+// WithHooks sets hooks for the Result. The hooks that are set override the GlobalHooks.
+func WithHooks(h Hooks) InitResultOption {
+	return func(irp *InitResultParam) error {
+		irp.Hooks = h
+		return nil
+	}
+}
+
+// WithPreallocNotes reserves storage for n notes and their messages for hot paths.
+// The messages of new notes are then rendered through a reused buffer and appended
+// to the reserved storage instead of rebuilding all messages on every addition.
+// Copies of the Result returned by the Add methods share the reserved storage.
+func WithPreallocNotes(n int) InitResultOption {
+	return func(irp *InitResultParam) error {
+		irp.PreallocNotes = n
+		return nil
+	}
+}
+
+// WithCoerceStatus sets CoerceStatus to also set the status of the Result to the coerced status
+func WithCoerceStatus(on bool) InitResultOption {
+	return func(irp *InitResultParam) error {
+		irp.CoerceStatus = on
+		return nil
+	}
+}
+
+// WithRawInitialMessage sets the initial message to be added as is as an application
+// message, instead of an information or error message depending on the status.
+// The message is not parsed for a type and is not formatted with the Operation.
+func WithRawInitialMessage(on bool) InitResultOption {
+	return func(irp *InitResultParam) error {
+		irp.RawInitialMessage = on
+		return nil
+	}
+}
+
+// WithClock sets the function that returns the current time of the notes and
+// phases, such as a fixed time for reproducible output. The default is time.Now.
+func WithClock(now func() time.Time) InitResultOption {
+	return func(irp *InitResultParam) error {
+		irp.Clock = now
+		return nil
+	}
+}
+
+// WithFocusControlFormatter sets the function that formats the field of a field
+// error into the focus control, such as adding a prefix or changing the case.
+// The default is the field as is.
+func WithFocusControlFormatter(fn func(field string) string) InitResultOption {
+	return func(irp *InitResultParam) error {
+		irp.FocusControlFormatter = fn
+		return nil
+	}
+}
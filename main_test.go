@@ -0,0 +1,213 @@
+package result
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestWithMaxMessageLength(t *testing.T) {
+	tests := []struct {
+		name string
+		max  int
+		msg  string
+		want string
+	}{
+		{"off", 0, "a long message", "INF: a long message"},
+		{"short", 20, "short", "INF: short"},
+		{"exact", 5, "exact", "INF: exact"},
+		{"cut", 6, "a long message", "INF: a long…"},
+		{"runes", 3, "ñéñéñ", "INF: ñéñ…"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := InitResult(WithStatus(OK), WithMaxMessageLength(tt.max))
+			r.AddInfo("%s", tt.msg)
+			if !reflect.DeepEqual(r.Messages, []string{tt.want}) {
+				t.Fatalf("got %q, want %q", r.Messages, tt.want)
+			}
+			if got := r.StructuredMessages()[0].Message; got != tt.msg {
+				t.Fatalf("got structured message %q, want %q", got, tt.msg)
+			}
+		})
+	}
+}
+
+func TestWithFailFast(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []InitResultOption
+		want []string
+	}{
+		{"off", nil, []string{"INF: a", "ERR: b", "WRN: c", "INF: d", "SUC: e", "ERR: f"}},
+		{"on", []InitResultOption{WithFailFast(true)}, []string{"INF: a", "ERR: b", "ERR: f"}},
+		{"warnings as errors", []InitResultOption{WithFailFast(true), WithWarningsAsErrors(true)},
+			[]string{"INF: a", "ERR: b", "WRN: c", "ERR: f"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := InitResult(append([]InitResultOption{WithStatus(OK)}, tt.opts...)...)
+			r.AddInfo("a")
+			r.AddError("b")
+			r.AddWarning("c")
+			r.AddInfoLazy(func() string { return "d" })
+			r.AddSuccess("e")
+			r.AddError("f")
+			r.MessagesToString()
+			if !reflect.DeepEqual(r.Messages, tt.want) {
+				t.Fatalf("got %q, want %q", r.Messages, tt.want)
+			}
+			if r.Status != string(OK) {
+				t.Fatalf("fail-fast changed the status to %s", r.Status)
+			}
+		})
+	}
+}
+
+func TestWithMessageFormatter(t *testing.T) {
+	bracket := func(m Message) string { return "[" + string(m.Type) + "|" + m.Prefix + "] " + m.Message }
+	tests := []struct {
+		name     string
+		prealloc int
+	}{
+		{"default storage", 0},
+		{"prealloc", 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := InitResult(WithStatus(OK), WithPrefix("svc"), WithPreallocNotes(tt.prealloc), WithMessageFormatter(bracket))
+			r.AddInfo("one")
+			r.AddError("two")
+			want := []string{"[INF|svc] one", "[ERR|svc] two"}
+			if !reflect.DeepEqual(r.Messages, want) {
+				t.Fatalf("got %q, want %q", r.Messages, want)
+			}
+			if got := r.StructuredMessages()[1].Message; got != "two" {
+				t.Fatalf("got structured message %q", got)
+			}
+		})
+	}
+}
+
+func TestWithTimeLocation(t *testing.T) {
+	at := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	manila := time.FixedZone("PHT", 8*3600)
+	tests := []struct {
+		name string
+		loc  *time.Location
+		want string
+	}{
+		{"default utc", nil, "2024-03-01T12:00:00Z"},
+		{"fixed zone", manila, "2024-03-01T20:00:00+08:00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := InitResult(WithStatus(OK), WithTimeLocation(tt.loc), WithClock(func() time.Time { return at }))
+			r.AddInfo("one")
+			m := r.StructuredMessages()[0]
+			if m.Time == nil || m.Time.Format(time.RFC3339) != tt.want || !m.Time.Equal(at) {
+				t.Fatalf("got %v, want %s", m.Time, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithWarningsAsErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		on     bool
+		add    func(r *Result)
+		errors bool
+		want   string
+	}{
+		{"info", true, func(r *Result) { r.AddInfo("a") }, false, "INF: a"},
+		{"warning off", false, func(r *Result) { r.AddWarning("a") }, false, "WRN: a"},
+		{"warning on", true, func(r *Result) { r.AddWarning("a") }, true, "WRN: a"},
+		{"error off", false, func(r *Result) { r.AddError("a") }, true, "ERR: a"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := InitResult(WithStatus(OK), WithWarningsAsErrors(tt.on))
+			tt.add(&r)
+			if got := r.HasErrors(); got != tt.errors {
+				t.Fatalf("got HasErrors %v, want %v", got, tt.errors)
+			}
+			if !reflect.DeepEqual(r.Messages, []string{tt.want}) {
+				t.Fatalf("got %q, want %q", r.Messages, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithMinSeverity(t *testing.T) {
+	tests := []struct {
+		name string
+		min  Severity
+		want []string
+		str  string
+	}{
+		{"none", SeverityNone, []string{"raw", "INF: a", "WRN: b", "ERR: c"}, "raw\nINF: a\nWRN: b\nERR: c\n"},
+		{"warning", SeverityWarning, []string{"WRN: b", "ERR: c"}, "WRN: b\nERR: c\n"},
+		{"error", SeverityError, []string{"ERR: c"}, "ERR: c"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := InitResult(WithStatus(OK), WithMinSeverity(tt.min))
+			r.osIsWin = false
+			r.AddRawMsg("raw")
+			r.AddInfo("a")
+			r.AddWarning("b")
+			r.AddError("c")
+			if !reflect.DeepEqual(r.Messages, tt.want) {
+				t.Fatalf("got %q, want %q", r.Messages, tt.want)
+			}
+			if got := r.MessagesToString(); got != tt.str {
+				t.Fatalf("got %q, want %q", got, tt.str)
+			}
+			if got := len(r.StructuredMessages()); got != 4 {
+				t.Fatalf("got %d structured messages", got)
+			}
+		})
+	}
+}
+
+func TestWithMinSeverityFiltersAll(t *testing.T) {
+	r := InitResult(WithStatus(OK), WithMinSeverity(SeverityError))
+	r.AddInfo("a")
+	if got := r.MessagesToString(); got != "" || len(r.Messages) != 0 {
+		t.Fatalf("got %q and %q", got, r.Messages)
+	}
+}
+
+func TestWithIncludeErrorType(t *testing.T) {
+	pathErr := &fs.PathError{Op: "open", Path: "a.txt", Err: fs.ErrNotExist}
+	tests := []struct {
+		name    string
+		on      bool
+		err     error
+		want    string
+		errType string
+	}{
+		{"off", false, pathErr, "ERR: open a.txt: file does not exist", ""},
+		{"on", true, pathErr, "ERR: *fs.PathError: open a.txt: file does not exist", "*fs.PathError"},
+		{"wrapped", true, fmt.Errorf("load: %w", pathErr), "ERR: *fmt.wrapError: load: open a.txt: file does not exist", "*fmt.wrapError"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := InitResult(WithIncludeErrorType(tt.on))
+			r.AddErr(tt.err)
+			if !reflect.DeepEqual(r.Messages, []string{tt.want}) {
+				t.Fatalf("got %q, want %q", r.Messages, tt.want)
+			}
+			if got := r.StructuredMessages()[0].ErrorType; got != tt.errType {
+				t.Fatalf("got error type %q, want %q", got, tt.errType)
+			}
+			if !errors.Is(r.ToError(), fs.ErrNotExist) {
+				t.Fatalf("error not retained: %v", r.ToError())
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package result
+
+import (
+	"fmt"
+	"slices"
+)
+
+// ResultMatcher matches results by status and rendered messages, ignoring
+// timing and other fields. It implements the Matches and String methods of
+// the gomock and testify matcher interfaces without importing them.
+type ResultMatcher struct {
+	Expected Result
+}
+
+// MatchResult returns a function that checks if a Result has the status and
+// rendered messages of the expected Result
+func MatchResult(expected Result) func(Result) bool {
+	m := ResultMatcher{Expected: expected}
+	return func(r Result) bool {
+		return m.Matches(r)
+	}
+}
+
+// Matches checks if x is a Result or *Result with the expected status and messages
+func (m ResultMatcher) Matches(x any) bool {
+	var r *Result
+	switch v := x.(type) {
+	case Result:
+		r = &v
+	case *Result:
+		r = v
+	default:
+		return false
+	}
+	if r == nil {
+		return false
+	}
+	r.resolveLazy()
+	m.Expected.resolveLazy()
+	return r.Status == m.Expected.Status && slices.Equal(r.Messages, m.Expected.Messages)
+}
+
+// String describes the expected Result
+func (m ResultMatcher) String() string {
+	return fmt.Sprintf("result with status %s and messages %q", m.Expected.Status, m.Expected.Messages)
+}
@@ -0,0 +1,44 @@
+package result
+
+import "testing"
+
+func TestResultMatcher(t *testing.T) {
+	want := InitResult(WithStatus(INVALID))
+	want.AddError("bad input")
+	res := func(s Status, msgs ...string) Result {
+		r := InitResult(WithStatus(s))
+		for _, m := range msgs {
+			r.AddError("%s", m)
+		}
+		return r
+	}
+	same := res(INVALID, "bad input")
+	tests := []struct {
+		name string
+		x    any
+		want bool
+	}{
+		{"value", res(INVALID, "bad input"), true},
+		{"pointer", &same, true},
+		{"other status", res(EXCEPTION, "bad input"), false},
+		{"other message", res(INVALID, "bad"), false},
+		{"extra message", res(INVALID, "bad input", "more"), false},
+		{"nil pointer", (*Result)(nil), false},
+		{"other type", "INVALID", false},
+	}
+	m := ResultMatcher{Expected: want}
+	match := MatchResult(want)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := m.Matches(tt.x); got != tt.want {
+				t.Fatalf("Matches got %v, want %v", got, tt.want)
+			}
+			if r, ok := tt.x.(Result); ok && match(r) != tt.want {
+				t.Fatalf("MatchResult got %v, want %v", !tt.want, tt.want)
+			}
+		})
+	}
+	if got := m.String(); got != `result with status INVALID and messages ["ERR: bad input"]` {
+		t.Fatalf("got %q", got)
+	}
+}
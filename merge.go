@@ -0,0 +1,74 @@
+package result
+
+// MergeWith appends the messages of another Result and merges its fields, and returns itself.
+//
+// For each of the fields status, operation, task_id, worker_id, focus_control,
+// page, page_count and page_size that is set in both results to different values,
+// the resolver is called with the field name and the values of both results,
+// and the returned value is used. The values are passed as Status for the status,
+// int for the page fields, and string for the others. When the resolver is nil or
+// returns nil or a value of another type, the status of higher severity is kept,
+// preferring the status of the Result, and the other fields of the Result are kept.
+// A field that is set only in the other result is copied. The status is set by Return,
+// so the allowed transitions apply.
+func (r *Result) MergeWith(other Result, resolver func(field string, a, b any) any) Result {
+	r.appendNotes(other)
+	r.errs = append(r.errs, other.errs...)
+	r.updateMessage()
+
+	st := Status(r.Status)
+	if os := Status(other.Status); os != "" && os != st {
+		def := st
+		if st == "" || os.Severity() > st.Severity() {
+			def = os
+		}
+		r.Return(resolveField(resolver, "status", st, os, def))
+	}
+	r.Operation = resolveString(resolver, "operation", r.Operation, other.Operation)
+	mergePtr(resolver, "task_id", &r.TaskID, other.TaskID)
+	mergePtr(resolver, "worker_id", &r.WorkerID, other.WorkerID)
+	mergePtr(resolver, "focus_control", &r.FocusControl, other.FocusControl)
+	mergePtr(resolver, "page", &r.Page, other.Page)
+	mergePtr(resolver, "page_count", &r.PageCount, other.PageCount)
+	mergePtr(resolver, "page_size", &r.PageSize, other.PageSize)
+	return *r
+}
+
+// resolveField returns the value of the resolver if it is of type T, or the default value
+func resolveField[T any](resolver func(field string, a, b any) any, field string, a, b, def T) T {
+	if resolver == nil {
+		return def
+	}
+	if v, ok := resolver(field, a, b).(T); ok {
+		return v
+	}
+	return def
+}
+
+// resolveString merges a string field where an empty string is not set
+func resolveString(resolver func(field string, a, b any) any, field, a, b string) string {
+	switch {
+	case b == "" || a == b:
+		return a
+	case a == "":
+		return b
+	}
+	return resolveField(resolver, field, a, b, a)
+}
+
+// mergePtr merges a pointer field where nil is not set. The value is copied
+// so that the results do not share it.
+func mergePtr[T comparable](resolver func(field string, a, b any) any, field string, a **T, b *T) {
+	switch {
+	case b == nil:
+		return
+	case *a == nil:
+		v := *b
+		*a = &v
+		return
+	case **a == *b:
+		return
+	}
+	v := resolveField(resolver, field, **a, *b, **a)
+	*a = &v
+}
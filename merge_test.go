@@ -0,0 +1,53 @@
+package result
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMergeWith(t *testing.T) {
+	p1, p3 := 1, 3
+	a := InitResult(WithStatus(OK), WithFocusControl("name"))
+	a.Page = &p1
+	a.AddInfo("a")
+	b := InitResult(WithStatus(INVALID), WithFocusControl("email"))
+	b.Page = &p3
+	b.AddError("b")
+	a.MergeWith(b, func(field string, x, y any) any {
+		switch field {
+		case "page":
+			return max(x.(int), y.(int))
+		case "focus_control":
+			return y
+		}
+		return nil
+	})
+	if *a.Page != 3 || *a.FocusControl != "email" || a.Status != string(INVALID) {
+		t.Fatalf("got page %d focus %s status %s", *a.Page, *a.FocusControl, a.Status)
+	}
+	if want := []string{"INF: a", "ERR: b"}; !reflect.DeepEqual(a.Messages, want) {
+		t.Fatalf("got %q", a.Messages)
+	}
+	if *b.Page != 3 {
+		t.Fatal("other result changed")
+	}
+}
+
+func TestMergeWithDefaults(t *testing.T) {
+	a := InitResult(WithStatus(EXCEPTION), WithFocusControl("name"))
+	b := InitResult(WithStatus(OK), WithFocusControl("email"))
+	a.MergeWith(b, func(string, any, any) any { return nil })
+	if a.Status != string(EXCEPTION) || *a.FocusControl != "name" {
+		t.Fatalf("got status %s focus %s", a.Status, *a.FocusControl)
+	}
+}
+
+func TestMergeWithStrictTransitions(t *testing.T) {
+	a := InitResult(WithStatus(OK), WithStrictTransitions(true))
+	a.SetAllowedTransitions(map[Status][]Status{OK: {}})
+	b := InitResult(WithStatus(EXCEPTION))
+	a.MergeWith(b, nil)
+	if a.Status != string(OK) || !a.HasErrors() {
+		t.Fatalf("got status %s %q", a.Status, a.Messages)
+	}
+}
@@ -0,0 +1,300 @@
+package result
+
+import (
+	"hash/fnv"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	l "github.com/stdutil/log"
+)
+
+// noteMeta is the metadata of a note kept alongside the notes of the message manager
+type noteMeta struct {
+	original string         // untruncated message
+	time     time.Time      // time the note was added
+	errType  string         // type of the error added by AddErr
+	step     string         // pipeline step that added the note
+	field    string         // field of a field error
+	attrs    map[string]any // attributes of the note
+	op       string         // operation of the Result the note was added to
+	seq      uint64         // sequence number of the note in the Result
+	lazy     *lazyMsg       // produces the message when it is rendered
+	group    string         // group of a grouped error
+	groupMsg string         // message of a grouped error in its group
+}
+
+// StructuredMessages returns the notes of the Result as structured messages.
+// Messages truncated by WithMaxMessageLength are returned in full.
+func (r *Result) StructuredMessages() []Message {
+	r.resolveLazy()
+	nts := r.ln.Notes()
+	msgs := make([]Message, 0, len(nts))
+	for i, n := range nts {
+		msgs = append(msgs, r.toMessage(i, n))
+	}
+	return msgs
+}
+
+// DrainMessages returns the structured messages and clears the notes, messages,
+// grouped errors and retained errors of the Result. The status and other fields are kept.
+func (r *Result) DrainMessages() []Message {
+	msgs := r.StructuredMessages()
+	r.ln.Clear()
+	r.nmeta = nil
+	r.errs = nil
+	r.groups = nil
+	r.Messages = make([]string, 0)
+	r.rendered = 0
+	return msgs
+}
+
+// Partition groups the structured messages by their note type in a single pass.
+// Errors include fatal notes, and infos include success and application messages.
+// The insertion order is preserved within each group.
+func (r *Result) Partition() (errors, warnings, infos []Message) {
+	r.resolveLazy()
+	for i, n := range r.ln.Notes() {
+		switch n.Type {
+		case l.Error, l.Fatal:
+			errors = append(errors, r.toMessage(i, n))
+		case l.Warn:
+			warnings = append(warnings, r.toMessage(i, n))
+		default:
+			infos = append(infos, r.toMessage(i, n))
+		}
+	}
+	return
+}
+
+func (r *Result) toMessage(i int, n l.LogInfo) Message {
+	m := Message{
+		Type:    n.Type,
+		Prefix:  n.Prefix,
+		Message: n.Message,
+	}
+	meta := r.metaOf(i)
+	if meta.original != "" {
+		m.Message = meta.original
+	}
+	m.ErrorType = meta.errType
+	m.Step = meta.step
+	m.Field = meta.field
+	m.Attrs = copyAttrs(meta.attrs)
+	m.Operation = meta.op
+	m.Seq = meta.seq
+	if !meta.time.IsZero() {
+		t := meta.time.In(r.location())
+		m.Time = &t
+	}
+	return m
+}
+
+// rebuildNotes rebuilds the notes of the message manager from the messages,
+// such as after the messages were unmarshalled
+func (r *Result) rebuildNotes() {
+	r.ln = l.Log{Prefix: r.Prefix}
+	r.nmeta = nil
+	r.rendered = len(r.Messages) // the messages are already rendered
+	for _, m := range r.Messages {
+		r.ln.Append(parseNote(m, r.Prefix))
+	}
+}
+
+// parseNote parses a message rendered by the message manager back into a note
+func parseNote(s, prefix string) l.LogInfo {
+	for _, typ := range []l.LogType{l.Info, l.Warn, l.Error, l.Fatal, l.Success} {
+		rest, ok := strings.CutPrefix(s, string(typ))
+		if !ok {
+			continue
+		}
+		pfx := ""
+		if strings.HasPrefix(rest, "[") {
+			pos := strings.Index(rest, "]")
+			if pos == -1 {
+				continue
+			}
+			pfx, rest = rest[1:pos], rest[pos+1:]
+		}
+		if msg, ok := strings.CutPrefix(rest, l.DelimMsgType); ok {
+			return l.LogInfo{Type: typ, Prefix: pfx, Message: msg}
+		}
+	}
+	return l.LogInfo{Type: l.App, Prefix: prefix, Message: s}
+}
+
+// location returns the location of the message timestamps
+func (r *Result) location() *time.Location {
+	if r.timeLoc == nil {
+		return time.UTC
+	}
+	return r.timeLoc
+}
+
+// metaOf returns the metadata of the note at the index
+func (r *Result) metaOf(i int) noteMeta {
+	if i >= 0 && i < len(r.nmeta) {
+		return r.nmeta[i]
+	}
+	return noteMeta{}
+}
+
+// setLastMeta sets the metadata of the last added note
+func (r *Result) setLastMeta(m noteMeta) {
+	r.alignMeta(len(r.ln.Notes()) - 1)
+	r.nmeta = append(r.nmeta, m)
+}
+
+// alignMeta pads or cuts the metadata to n entries.
+// Notes appended directly to the message manager have no metadata.
+func (r *Result) alignMeta(n int) {
+	if n < 0 {
+		n = 0
+	}
+	if len(r.nmeta) > n {
+		r.nmeta = r.nmeta[:n]
+		return
+	}
+	for len(r.nmeta) < n {
+		r.nmeta = append(r.nmeta, noteMeta{})
+	}
+}
+
+// appendNotes appends the notes of a Result with their metadata.
+// If types are given, only the notes of those types are appended.
+// When dedup on merge is on, notes that are already present are skipped.
+// The appended notes get the next sequence numbers of the Result, so that the
+// sequence numbers follow the order of the notes.
+func (r *Result) appendNotes(rs Result, types ...l.LogType) {
+	var ids map[uint64]bool
+	if r.dedupMerge {
+		ids = make(map[uint64]bool)
+		for _, n := range r.ln.Notes() {
+			ids[noteID(n)] = true
+		}
+	}
+	r.alignMeta(len(r.ln.Notes()))
+	for i, n := range rs.ln.Notes() {
+		if len(types) > 0 && !slices.Contains(types, n.Type) {
+			continue
+		}
+		if ids != nil && rs.metaOf(i).lazy == nil {
+			id := noteID(n)
+			if ids[id] {
+				continue
+			}
+			ids[id] = true
+		}
+		r.ln.Append(n)
+		meta := rs.metaOf(i)
+		r.seq++
+		meta.seq = r.seq
+		r.nmeta = append(r.nmeta, meta)
+	}
+}
+
+// noteID returns a stable id of a note from its type, prefix and message
+func noteID(n l.LogInfo) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(n.Type))
+	h.Write([]byte{0})
+	h.Write([]byte(n.Prefix))
+	h.Write([]byte{0})
+	h.Write([]byte(n.Message))
+	return h.Sum64()
+}
+
+// truncateRunes cuts s to n runes with an ellipsis. It returns false if s was not cut.
+func truncateRunes(s string, n int) (string, bool) {
+	if n <= 0 || utf8.RuneCountInString(s) <= n {
+		return s, false
+	}
+	i := 0
+	for pos := range s {
+		if i == n {
+			return s[:pos] + "…", true
+		}
+		i++
+	}
+	return s, false
+}
+
+// copyAttrs deep-copies attributes so that they are not aliased
+func copyAttrs(attrs map[string]any) map[string]any {
+	if attrs == nil {
+		return nil
+	}
+	return copyAttr(attrs).(map[string]any)
+}
+
+// copyAttr deep-copies the maps, slices and arrays in an attribute.
+// Pointers, structs and the other values in interfaces are copied as is, so that
+// errors keep their identity, and maps and slices that contain themselves are
+// copied once.
+func copyAttr(v any) any {
+	if v == nil {
+		return nil
+	}
+	return copyValue(reflect.ValueOf(v), map[copiedKey]reflect.Value{}).Interface()
+}
+
+// copiedKey identifies a map or slice copied by copyValue
+type copiedKey struct {
+	ptr uintptr
+	typ reflect.Type
+	len int
+}
+
+// copyValue deep-copies the maps, slices and arrays in a value.
+// The copied maps and slices are kept in seen to copy cycles once.
+func copyValue(v reflect.Value, seen map[copiedKey]reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		k := copiedKey{v.Pointer(), v.Type(), 0}
+		if c, ok := seen[k]; ok {
+			return c
+		}
+		c := reflect.MakeMapWithSize(v.Type(), v.Len())
+		seen[k] = c
+		for it := v.MapRange(); it.Next(); {
+			c.SetMapIndex(it.Key(), copyValue(it.Value(), seen))
+		}
+		return c
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		k := copiedKey{v.Pointer(), v.Type(), v.Len()}
+		if c, ok := seen[k]; ok {
+			return c
+		}
+		c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		seen[k] = c
+		for i := range v.Len() {
+			c.Index(i).Set(copyValue(v.Index(i), seen))
+		}
+		return c
+	case reflect.Array:
+		c := reflect.New(v.Type()).Elem()
+		for i := range v.Len() {
+			c.Index(i).Set(copyValue(v.Index(i), seen))
+		}
+		return c
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		switch v.Elem().Kind() {
+		case reflect.Map, reflect.Slice, reflect.Array:
+			c := reflect.New(v.Type()).Elem()
+			c.Set(copyValue(v.Elem(), seen))
+			return c
+		}
+	}
+	return v
+}
@@ -0,0 +1,89 @@
+package result
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestPartition(t *testing.T) {
+	texts := func(ms []Message) []string {
+		var s []string
+		for _, m := range ms {
+			s = append(s, string(m.Type)+" "+m.Message)
+		}
+		return s
+	}
+	tests := []struct {
+		name                    string
+		add                     func(r *Result)
+		errors, warnings, infos []string
+	}{
+		{"empty", func(r *Result) {}, nil, nil, nil},
+		{"mixed", func(r *Result) {
+			r.AddInfo("i1")
+			r.AddError("e1")
+			r.AddWarning("w1")
+			r.AddSuccess("s1")
+			r.AddRawMsg("raw")
+			r.AddError("e2")
+		}, []string{"ERR e1", "ERR e2"}, []string{"WRN w1"}, []string{"INF i1", "SUC s1", " raw"}},
+		{"fatal", func(r *Result) {
+			r.AddRawMsg("FTL[]stop")
+		}, []string{"FTL stop"}, nil, nil},
+		{"lazy", func(r *Result) {
+			r.AddWarningLazy(func() string { return "later" })
+		}, nil, []string{"WRN later"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := InitResult(WithStatus(OK))
+			tt.add(&r)
+			errs, warns, infos := r.Partition()
+			if got := texts(errs); !reflect.DeepEqual(got, tt.errors) {
+				t.Fatalf("got errors %q, want %q", got, tt.errors)
+			}
+			if got := texts(warns); !reflect.DeepEqual(got, tt.warnings) {
+				t.Fatalf("got warnings %q, want %q", got, tt.warnings)
+			}
+			if got := texts(infos); !reflect.DeepEqual(got, tt.infos) {
+				t.Fatalf("got infos %q, want %q", got, tt.infos)
+			}
+		})
+	}
+}
+
+func TestDrainMessages(t *testing.T) {
+	tests := []struct {
+		name     string
+		prealloc int
+	}{
+		{"default storage", 0},
+		{"prealloc", 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := InitResult(WithStatus(INVALID), WithPreallocNotes(tt.prealloc))
+			r.AddInfo("a")
+			r.AddErr(errors.New("b"))
+			r.AddGroupedError("g", "c")
+			msgs := r.DrainMessages()
+			if len(msgs) != 3 || msgs[1].Message != "b" {
+				t.Fatalf("got %+v", msgs)
+			}
+			if len(r.Messages) != 0 || len(r.StructuredMessages()) != 0 || len(r.GroupedErrors()) != 0 || r.ToError() != nil {
+				t.Fatalf("not cleared: %q", r.Messages)
+			}
+			if r.Status != string(INVALID) {
+				t.Fatalf("status changed to %s", r.Status)
+			}
+			r.AddInfo("d")
+			if want := []string{"INF: d"}; !reflect.DeepEqual(r.Messages, want) {
+				t.Fatalf("got %q, want %q", r.Messages, want)
+			}
+			if got := r.DrainMessages(); len(got) != 1 || got[0].Seq != 4 {
+				t.Fatalf("got %+v", got)
+			}
+		})
+	}
+}
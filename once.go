@@ -0,0 +1,32 @@
+package result
+
+import l "github.com/stdutil/log"
+
+// AddInfoOnce adds a formatted information message only if the key was not used
+// before by the Add once methods of this Result. It returns itself.
+func (r *Result) AddInfoOnce(key, fmtMsg string, a ...any) Result {
+	return r.addOnce(key, l.Info, fmtMsg, a...)
+}
+
+// AddWarningOnce adds a formatted warning message only if the key was not used
+// before by the Add once methods of this Result. It returns itself.
+func (r *Result) AddWarningOnce(key, fmtMsg string, a ...any) Result {
+	return r.addOnce(key, l.Warn, fmtMsg, a...)
+}
+
+// AddErrorOnce adds a formatted error message only if the key was not used
+// before by the Add once methods of this Result. It returns itself.
+func (r *Result) AddErrorOnce(key, fmtMsg string, a ...any) Result {
+	return r.addOnce(key, l.Error, fmtMsg, a...)
+}
+
+func (r *Result) addOnce(key string, typ l.LogType, fmtMsg string, a ...any) Result {
+	if r.onceKeys[key] {
+		return *r
+	}
+	if r.onceKeys == nil {
+		r.onceKeys = make(map[string]bool)
+	}
+	r.onceKeys[key] = true
+	return r.add(typ, fmtMsg, a...)
+}
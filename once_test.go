@@ -0,0 +1,56 @@
+package result
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAddOnce(t *testing.T) {
+	tests := []struct {
+		name string
+		add  func(r *Result)
+		want []string
+	}{
+		{"same key", func(r *Result) {
+			r.AddInfoOnce("k", "first %d", 1)
+			r.AddInfoOnce("k", "second")
+		}, []string{"INF: first 1"}},
+		{"keys shared across types", func(r *Result) {
+			r.AddWarningOnce("k", "warning")
+			r.AddErrorOnce("k", "error")
+		}, []string{"WRN: warning"}},
+		{"different keys", func(r *Result) {
+			r.AddErrorOnce("a", "a")
+			r.AddErrorOnce("b", "b")
+		}, []string{"ERR: a", "ERR: b"}},
+		{"other add methods ignore keys", func(r *Result) {
+			r.AddInfoOnce("k", "once")
+			r.AddInfo("once")
+		}, []string{"INF: once", "INF: once"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := InitResult(WithStatus(OK))
+			tt.add(&r)
+			if !reflect.DeepEqual(r.Messages, tt.want) {
+				t.Fatalf("got %q, want %q", r.Messages, tt.want)
+			}
+		})
+	}
+}
+
+func TestAddOnceKeysAreNotShared(t *testing.T) {
+	r := InitResult(WithStatus(OK))
+	r.AddInfoOnce("k", "first")
+	c := r.clone(map[*Result]bool{})
+	c.AddInfoOnce("other", "clone")
+	r.AddInfoOnce("other", "original")
+	if want := []string{"INF: first", "INF: original"}; !reflect.DeepEqual(r.Messages, want) {
+		t.Fatalf("got %q, want %q", r.Messages, want)
+	}
+	r.Reset()
+	r.AddInfoOnce("k", "after reset")
+	if want := []string{"INF: after reset"}; !reflect.DeepEqual(r.Messages, want) {
+		t.Fatalf("got %q, want %q", r.Messages, want)
+	}
+}
@@ -0,0 +1,75 @@
+package result
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestOperationInMessages(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []InitResultOption
+		want []string
+	}{
+		{"initial only", []InitResultOption{UseOperationInMessage(true)},
+			[]string{"INF: func1: saved", "INF: next"}},
+		{"all messages", []InitResultOption{WithOperationInAllMessages(true)},
+			[]string{"INF: func1: saved", "INF: func1: next"}},
+		{"both", []InitResultOption{UseOperationInMessage(true), WithOperationInAllMessages(true)},
+			[]string{"INF: func1: saved", "INF: func1: next"}},
+		{"custom format", []InitResultOption{WithOperationInAllMessages(true), WithOperationMessageFormat("[%s] %s")},
+			[]string{"INF: [func1] saved", "INF: [func1] next"}},
+		{"none", nil, []string{"INF: saved", "INF: next"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := InitResult(append([]InitResultOption{WithStatus(OK), WithMessage("saved")}, tt.opts...)...)
+			r.AddInfo("next")
+			if !reflect.DeepEqual(r.Messages, tt.want) {
+				t.Fatalf("got %q, want %q", r.Messages, tt.want)
+			}
+			if err := r.InitError(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestOperationMessageFormatInvalid(t *testing.T) {
+	for _, f := range []string{"[%d]", "%s", "%s %s %s", "%s %v"} {
+		r := InitResult(WithOperationInAllMessages(true), WithOperationMessageFormat(f))
+		if r.InitError() == nil {
+			t.Fatalf("no error for %q", f)
+		}
+	}
+}
+
+//go:noinline
+func detectIn(r *Result, skip int) { r.DetectOperation(skip) }
+
+//go:noinline
+func saveOrder(r *Result, skip int) { detectIn(r, skip) }
+
+func TestDetectOperation(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      []InitResultOption
+		skip      int
+		operation string
+		eventID   string
+	}{
+		{"caller", nil, 0, "detectin", "detectined"},
+		{"caller of caller", nil, 1, "saveorder", "saveordered"},
+		{"custom verb kept", []InitResultOption{WithEventVerb("load")}, 1, "saveorder", "loaded"},
+		{"unresolved", nil, 1000, "func1", "func1ed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := InitResult(tt.opts...)
+			saveOrder(&r, tt.skip)
+			if r.Operation != tt.operation || r.EventID() != tt.eventID {
+				t.Fatalf("got operation %q, event id %q", r.Operation, r.EventID())
+			}
+		})
+	}
+}
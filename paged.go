@@ -0,0 +1,214 @@
+package result
+
+import (
+	"net/http"
+	"time"
+)
+
+// NewPagedResult creates a PagedResult with the items of a page. The page count
+// is computed from the total number of items and the page size. The status is
+// set to OK unless a status is set by the options.
+func NewPagedResult[T any](items []T, page, size int, total int64, opts ...InitResultOption) PagedResult[T] {
+	res := PagedResult[T]{
+		Items: items,
+		Total: total,
+	}
+	if res.Items == nil {
+		res.Items = make([]T, 0)
+	}
+	res.Result.init(2, append([]InitResultOption{WithStatus(OK)}, opts...)...)
+	count := 0
+	if size > 0 {
+		count = int((total + int64(size) - 1) / int64(size))
+	}
+	res.Result.Page = &page
+	res.Result.PageSize = &size
+	res.Result.PageCount = &count
+	return res
+}
+
+// CurrentPage returns the current page
+func (r *PagedResult[T]) CurrentPage() int {
+	return derefInt(r.Result.Page)
+}
+
+// TotalPages returns the page count
+func (r *PagedResult[T]) TotalPages() int {
+	return derefInt(r.Result.PageCount)
+}
+
+// PageSize returns the page size
+func (r *PagedResult[T]) PageSize() int {
+	return derefInt(r.Result.PageSize)
+}
+
+// AddInfo adds an information message and returns itself
+func (r *PagedResult[T]) AddInfo(fmtMsg string, a ...any) PagedResult[T] {
+	r.Result.AddInfo(fmtMsg, a...)
+	return *r
+}
+
+// AddWarning adds a warning message and returns itself
+func (r *PagedResult[T]) AddWarning(fmtMsg string, a ...any) PagedResult[T] {
+	r.Result.AddWarning(fmtMsg, a...)
+	return *r
+}
+
+// AddError adds an error message and returns itself
+func (r *PagedResult[T]) AddError(fmtMsg string, a ...any) PagedResult[T] {
+	r.Result.AddError(fmtMsg, a...)
+	return *r
+}
+
+// AddErr adds a error-typed value and returns itself.
+func (r *PagedResult[T]) AddErr(err error) PagedResult[T] {
+	r.Result.AddErr(err)
+	return *r
+}
+
+// AddSuccess adds an success message and returns itself
+func (r *PagedResult[T]) AddSuccess(fmtMsg string, a ...any) PagedResult[T] {
+	r.Result.AddSuccess(fmtMsg, a...)
+	return *r
+}
+
+// Stuff adds or appends the messages of a Result.
+func (r *PagedResult[T]) Stuff(rs Result) PagedResult[T] {
+	r.Result.Stuff(rs)
+	return *r
+}
+
+// AddErrWithAlt adds an error-typed value, and an alternate error
+// message if the err happens to be nil. It returns itself.
+func (r *PagedResult[T]) AddErrWithAlt(err error, altMsg string, altMsgValues ...any) PagedResult[T] {
+	r.Result.AddErrWithAlt(err, altMsg, altMsgValues...)
+	return *r
+}
+
+// AddErrorWithAlt appends the messages of a Result.
+// And an alternative message if the Result is other than OK or VALID status.
+func (r *PagedResult[T]) AddErrorWithAlt(rs Result, altMsg string, altMsgValues ...any) PagedResult[T] {
+	r.Result.AddErrorWithAlt(rs, altMsg, altMsgValues...)
+	return *r
+}
+
+// Return sets the current status of a result
+func (r *PagedResult[T]) Return(status Status) PagedResult[T] {
+	r.Result.Return(status)
+	return *r
+}
+
+// AddInfoText adds an information message as is, without formatting, and returns itself
+func (r *PagedResult[T]) AddInfoText(msg string) PagedResult[T] {
+	r.Result.AddInfoText(msg)
+	return *r
+}
+
+// AddWarningText adds a warning message as is, without formatting, and returns itself
+func (r *PagedResult[T]) AddWarningText(msg string) PagedResult[T] {
+	r.Result.AddWarningText(msg)
+	return *r
+}
+
+// AddErrorText adds an error message as is, without formatting, and returns itself
+func (r *PagedResult[T]) AddErrorText(msg string) PagedResult[T] {
+	r.Result.AddErrorText(msg)
+	return *r
+}
+
+// AddSuccessText adds a success message as is, without formatting, and returns itself
+func (r *PagedResult[T]) AddSuccessText(msg string) PagedResult[T] {
+	r.Result.AddSuccessText(msg)
+	return *r
+}
+
+// AddInfoOnce adds an information message only if the key was not used before.
+// It returns itself.
+func (r *PagedResult[T]) AddInfoOnce(key, fmtMsg string, a ...any) PagedResult[T] {
+	r.Result.AddInfoOnce(key, fmtMsg, a...)
+	return *r
+}
+
+// AddWarningOnce adds a warning message only if the key was not used before.
+// It returns itself.
+func (r *PagedResult[T]) AddWarningOnce(key, fmtMsg string, a ...any) PagedResult[T] {
+	r.Result.AddWarningOnce(key, fmtMsg, a...)
+	return *r
+}
+
+// AddErrorOnce adds an error message only if the key was not used before.
+// It returns itself.
+func (r *PagedResult[T]) AddErrorOnce(key, fmtMsg string, a ...any) PagedResult[T] {
+	r.Result.AddErrorOnce(key, fmtMsg, a...)
+	return *r
+}
+
+// AddTemplatedError adds an error message rendered from a template with named
+// placeholders resolved from data, and returns itself
+func (r *PagedResult[T]) AddTemplatedError(tmpl string, data map[string]any) PagedResult[T] {
+	r.Result.AddTemplatedError(tmpl, data)
+	return *r
+}
+
+// AddFieldError adds an error message for a field and returns itself
+func (r *PagedResult[T]) AddFieldError(field, fmtMsg string, a ...any) PagedResult[T] {
+	r.Result.AddFieldError(field, fmtMsg, a...)
+	return *r
+}
+
+// AddInfoLazy adds an information message produced by fn when it is rendered, and returns itself
+func (r *PagedResult[T]) AddInfoLazy(fn func() string) PagedResult[T] {
+	r.Result.AddInfoLazy(fn)
+	return *r
+}
+
+// AddWarningLazy adds a warning message produced by fn when it is rendered, and returns itself
+func (r *PagedResult[T]) AddWarningLazy(fn func() string) PagedResult[T] {
+	r.Result.AddWarningLazy(fn)
+	return *r
+}
+
+// AddErrorLazy adds an error message produced by fn when it is rendered, and returns itself
+func (r *PagedResult[T]) AddErrorLazy(fn func() string) PagedResult[T] {
+	r.Result.AddErrorLazy(fn)
+	return *r
+}
+
+// AddErrorWith adds a formatted error message with attributes and returns itself
+func (r *PagedResult[T]) AddErrorWith(attrs map[string]any, fmtMsg string, a ...any) PagedResult[T] {
+	r.Result.AddErrorWith(attrs, fmtMsg, a...)
+	return *r
+}
+
+// AddGroupedError adds a formatted error message to a group and returns itself
+func (r *PagedResult[T]) AddGroupedError(group, fmtMsg string, a ...any) PagedResult[T] {
+	r.Result.AddGroupedError(group, fmtMsg, a...)
+	return *r
+}
+
+// AddEscalatingWarning adds a formatted warning message that becomes an error once
+// the occurrences of the key exceed the escalation threshold, and returns itself
+func (r *PagedResult[T]) AddEscalatingWarning(key, fmtMsg string, a ...any) PagedResult[T] {
+	r.Result.AddEscalatingWarning(key, fmtMsg, a...)
+	return *r
+}
+
+// AddTimeout adds an error for an operation that timed out, sets the status to
+// TIMEOUT, marks the result as retryable and returns itself
+func (r *PagedResult[T]) AddTimeout(operation string, elapsed time.Duration) PagedResult[T] {
+	r.Result.AddTimeout(operation, elapsed)
+	return *r
+}
+
+// WriteHTTP writes the PagedResult as JSON with the mapped HTTP status code
+func (r *PagedResult[T]) WriteHTTP(w http.ResponseWriter) {
+	r.setCacheControl(w)
+	writeJSON(w, r.HTTPStatusCode(), r.MarshalJSON)
+}
+
+func derefInt(p *int) int {
+	if p == nil {
+		return 0
+	}
+	return *p
+}
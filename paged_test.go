@@ -0,0 +1,65 @@
+package result
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestNewPagedResult(t *testing.T) {
+	tests := []struct {
+		name       string
+		items      []string
+		page, size int
+		total      int64
+		opts       []InitResultOption
+		count      int
+		status     Status
+		wantItems  []string
+	}{
+		{"first page", []string{"a", "b"}, 1, 2, 5, nil, 3, OK, []string{"a", "b"}},
+		{"exact pages", []string{"a"}, 2, 5, 10, nil, 2, OK, []string{"a"}},
+		{"no items", nil, 1, 10, 0, nil, 0, OK, []string{}},
+		{"zero size", nil, 1, 0, 5, nil, 0, OK, []string{}},
+		{"status option", nil, 1, 10, 0, []InitResultOption{WithStatus(INVALID)}, 0, INVALID, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewPagedResult(tt.items, tt.page, tt.size, tt.total, tt.opts...)
+			if r.CurrentPage() != tt.page || r.PageSize() != tt.size || r.TotalPages() != tt.count || r.Total != tt.total {
+				t.Fatalf("got page %d, size %d, count %d, total %d", r.CurrentPage(), r.PageSize(), r.TotalPages(), r.Total)
+			}
+			if r.Status != string(tt.status) || !reflect.DeepEqual(r.Items, tt.wantItems) {
+				t.Fatalf("got status %s, items %v", r.Status, r.Items)
+			}
+		})
+	}
+}
+
+func TestPagedResultJSON(t *testing.T) {
+	r := NewPagedResult([]int{1, 2}, 1, 2, 3)
+	r.AddInfo("found %d", 3)
+	rec := httptest.NewRecorder()
+	r.WriteHTTP(rec)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("got code %d", rec.Code)
+	}
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(rec.Body.Bytes(), &m); err != nil {
+		t.Fatal(err)
+	}
+	for key, want := range map[string]string{"items": "[1,2]", "total": "3", "page_count": "2", "messages": `["INF: found 3"]`} {
+		if got := string(m[key]); got != want {
+			t.Errorf("got %s %s, want %s", key, got, want)
+		}
+	}
+	got := PagedResult[int]{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got.Items, r.Items) || got.Total != 3 || got.TotalPages() != 2 || !reflect.DeepEqual(got.Messages, r.Messages) {
+		t.Fatalf("got %+v", got)
+	}
+}
@@ -0,0 +1,45 @@
+package result
+
+// CombinePagination combines the pagination of the Result with other results,
+// such as pages from several paginated upstream calls. Nil fields are skipped.
+//
+//   - PageSize is the sum of the page sizes, as the combined page holds every page.
+//   - The total records is the sum of PageCount * PageSize of each result.
+//   - PageCount is recomputed as the total records divided by PageSize, rounded up.
+//   - Page is the highest page, capped at PageCount.
+//
+// The fields are not changed if no result has a page size.
+func (r *Result) CombinePagination(others ...Result) {
+	var (
+		size, total, page int
+		hasSize, hasPage  bool
+	)
+	for _, rs := range append([]Result{*r}, others...) {
+		if rs.PageSize != nil {
+			hasSize = true
+			size += *rs.PageSize
+			if rs.PageCount != nil {
+				total += *rs.PageCount * *rs.PageSize
+			}
+		}
+		if rs.Page != nil && (!hasPage || *rs.Page > page) {
+			hasPage = true
+			page = *rs.Page
+		}
+	}
+	if !hasSize {
+		return
+	}
+	count := 0
+	if size > 0 {
+		count = (total + size - 1) / size
+	}
+	if hasPage && page > count {
+		page = count
+	}
+	r.PageSize = &size
+	r.PageCount = &count
+	if hasPage {
+		r.Page = &page
+	}
+}
@@ -0,0 +1,44 @@
+package result
+
+import "testing"
+
+func TestCombinePagination(t *testing.T) {
+	paged := func(page, count, size *int) Result {
+		r := InitResult(WithStatus(OK))
+		r.Page, r.PageCount, r.PageSize = page, count, size
+		return r
+	}
+	n := func(v int) *int { return &v }
+	tests := []struct {
+		name              string
+		r                 Result
+		others            []Result
+		page, count, size *int
+	}{
+		{"no sizes", paged(n(1), nil, nil), []Result{paged(n(3), nil, nil)}, n(1), nil, nil},
+		{"two sources", paged(n(1), n(4), n(10)), []Result{paged(n(2), n(2), n(5))}, n(2), n(4), n(15)},
+		{"page capped", paged(n(9), n(1), n(10)), []Result{paged(nil, n(1), n(10))}, n(1), n(1), n(20)},
+		{"no page", paged(nil, n(3), n(10)), nil, nil, n(3), n(10)},
+		{"size without count", paged(n(1), nil, n(10)), []Result{paged(n(1), n(2), n(10))}, n(1), n(1), n(20)},
+		{"zero sizes", paged(n(1), n(1), n(0)), nil, n(0), n(0), n(0)},
+	}
+	deref := func(p *int) any {
+		if p == nil {
+			return nil
+		}
+		return *p
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := tt.r
+			r.CombinePagination(tt.others...)
+			got := []any{deref(r.Page), deref(r.PageCount), deref(r.PageSize)}
+			want := []any{deref(tt.page), deref(tt.count), deref(tt.size)}
+			for i := range got {
+				if got[i] != want[i] {
+					t.Fatalf("got page, count, size %v, want %v", got, want)
+				}
+			}
+		})
+	}
+}
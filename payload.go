@@ -0,0 +1,26 @@
+package result
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// MaxPayloadHashes is the maximum number of payload hashes kept by NotePayload
+var MaxPayloadHashes = 100
+
+// NotePayload adds an information message that the payload was processed, with its
+// size and SHA-256 hash, and keeps the hash in PayloadHashes by name.
+// Once MaxPayloadHashes payloads are kept, the hashes of new names are not kept,
+// but the message is still added.
+func (r *Result) NotePayload(name string, data []byte) {
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:])
+	r.AddInfo("processed %s (%d bytes, sha256=%s)", name, len(data), hash)
+	if r.PayloadHashes == nil {
+		r.PayloadHashes = make(map[string]string)
+	}
+	if _, ok := r.PayloadHashes[name]; !ok && len(r.PayloadHashes) >= MaxPayloadHashes {
+		return
+	}
+	r.PayloadHashes[name] = hash
+}
@@ -0,0 +1,52 @@
+package result
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"testing"
+)
+
+func TestNotePayload(t *testing.T) {
+	const emptyHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	tests := []struct {
+		name string
+		data []byte
+		msg  string
+	}{
+		{"empty", nil, "INF: processed doc (0 bytes, sha256=" + emptyHash + ")"},
+		{"abc", []byte("abc"), "INF: processed doc (3 bytes, sha256=ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := InitResult(WithStatus(OK))
+			r.NotePayload("doc", tt.data)
+			if len(r.Messages) != 1 || r.Messages[0] != tt.msg {
+				t.Fatalf("got %q, want %q", r.Messages, tt.msg)
+			}
+			if got := r.PayloadHashes["doc"]; got == "" || r.Messages[0][len(r.Messages[0])-65:len(r.Messages[0])-1] != got {
+				t.Fatalf("got hash %q", got)
+			}
+		})
+	}
+}
+
+func TestNotePayloadLimit(t *testing.T) {
+	defer func(n int) { MaxPayloadHashes = n }(MaxPayloadHashes)
+	MaxPayloadHashes = 2
+	r := InitResult(WithStatus(OK))
+	for i := range 3 {
+		r.NotePayload(fmt.Sprintf("p%d", i), []byte{byte(i)})
+	}
+	r.NotePayload("p0", []byte("changed"))
+	if len(r.Messages) != 4 || len(r.PayloadHashes) != 2 {
+		t.Fatalf("got %d messages and hashes %v", len(r.Messages), r.PayloadHashes)
+	}
+	if _, ok := r.PayloadHashes["p2"]; ok {
+		t.Fatal("hash kept over the limit")
+	}
+	sum := sha256.Sum256([]byte("changed"))
+	if r.PayloadHashes["p0"] != hex.EncodeToString(sum[:]) {
+		t.Fatalf("hash of a kept name not updated: %s", r.PayloadHashes["p0"])
+	}
+}
@@ -0,0 +1,65 @@
+package result
+
+import (
+	"slices"
+	"sync/atomic"
+	"time"
+)
+
+// ids of the phases, unique across results so that a phase is not confused
+// with a phase started after a Reset
+var phaseIDs atomic.Uint64
+
+// Phase is a named phase of an operation started by StartPhase
+type Phase struct {
+	Name     string        `json:"name"`               // Name of the phase
+	Start    time.Time     `json:"start"`              // Time the phase started
+	Duration time.Duration `json:"duration,omitempty"` // Duration of the phase, zero while it is open
+	Depth    int           `json:"depth,omitempty"`    // Number of phases open when the phase started
+	Done     bool          `json:"done"`               // The phase was completed
+	id       uint64
+}
+
+// StartPhase records the start of a named phase and returns a function that
+// completes it. Completing the phase adds an information message with the time
+// it took. Phases can be nested by starting a phase before completing another.
+// The returned function does nothing when called again, or after the phase
+// was removed such as by Reset.
+func (r *Result) StartPhase(name string) func() {
+	depth := 0
+	for _, p := range r.phases {
+		if !p.Done {
+			depth++
+		}
+	}
+	id := phaseIDs.Add(1)
+	r.phases = append(r.phases, Phase{
+		Name:  name,
+		Start: r.now(),
+		Depth: depth,
+		id:    id,
+	})
+	return func() {
+		i := slices.IndexFunc(r.phases, func(p Phase) bool { return p.id == id })
+		if i == -1 || r.phases[i].Done {
+			return
+		}
+		p := &r.phases[i]
+		p.Duration = r.now().Sub(p.Start)
+		p.Done = true
+		r.AddInfo("phase %s completed in %s", p.Name, p.Duration)
+	}
+}
+
+// Phases returns the phases started by StartPhase in the order they were started
+func (r *Result) Phases() []Phase {
+	return append([]Phase(nil), r.phases...)
+}
+
+// now returns the current time from the clock of the Result
+func (r *Result) now() time.Time {
+	if r.clock == nil {
+		return time.Now()
+	}
+	return r.clock()
+}
@@ -0,0 +1,60 @@
+package result
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// fixedClock returns a clock that advances by a second on each call
+func fixedClock() func() time.Time {
+	t := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	return func() time.Time {
+		t = t.Add(time.Second)
+		return t
+	}
+}
+
+func TestStartPhase(t *testing.T) {
+	r := InitResult(WithStatus(OK), WithClock(fixedClock()))
+	done := r.StartPhase("load")
+	done()
+	done()
+	done = r.StartPhase("save")
+	done()
+	want := []string{"INF: phase load completed in 1s", "INF: phase save completed in 1s"}
+	if !reflect.DeepEqual(r.Messages, want) {
+		t.Fatalf("got %q, want %q", r.Messages, want)
+	}
+	if ps := r.Phases(); len(ps) != 2 || !ps[0].Done || ps[1].Depth != 0 {
+		t.Fatalf("got %+v", ps)
+	}
+}
+
+func TestStartPhaseNested(t *testing.T) {
+	r := InitResult(WithStatus(OK), WithClock(fixedClock()))
+	outer := r.StartPhase("import") // the message of the inner phase reads the clock too
+	inner := r.StartPhase("parse")
+	inner()
+	outer()
+	ps := r.Phases()
+	if ps[1].Depth != 1 || ps[0].Duration != 4*time.Second || ps[1].Duration != time.Second {
+		t.Fatalf("got %+v", ps)
+	}
+}
+
+func TestStartPhaseAfterReset(t *testing.T) {
+	r := InitResult(WithStatus(OK))
+	done := r.StartPhase("load")
+	r.Reset()
+	other := r.StartPhase("other")
+	done()
+	if ps := r.Phases(); len(ps) != 1 || ps[0].Done || len(r.Messages) != 0 {
+		t.Fatalf("got %+v %q", ps, r.Messages)
+	}
+	other()
+	p := GetResult()
+	done = p.StartPhase("pooled")
+	PutResult(p)
+	done()
+}
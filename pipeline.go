@@ -0,0 +1,47 @@
+package result
+
+// Pipeline runs steps that accumulate their messages into one Result
+type Pipeline struct {
+	res           Result
+	continueOnErr bool
+	stopped       bool
+}
+
+// NewPipeline creates a Pipeline with a Result initialized with the options.
+// The operation is detected from the function that called NewPipeline.
+func NewPipeline(opts ...InitResultOption) *Pipeline {
+	p := &Pipeline{}
+	p.res.init(2, opts...)
+	return p
+}
+
+// ContinueOnError sets the Pipeline to run the next steps after a step returned an error
+func (p *Pipeline) ContinueOnError(on bool) *Pipeline {
+	p.continueOnErr = on
+	return p
+}
+
+// Step runs the step unless a previous step failed and the Pipeline stops on errors.
+// A returned error is added to the Result and sets the status to EXCEPTION.
+// The messages added by the step are attributed to the step name.
+func (p *Pipeline) Step(name string, fn func(*Result) error) *Pipeline {
+	if p.stopped || fn == nil {
+		return p
+	}
+	start := len(p.res.ln.Notes())
+	if err := fn(&p.res); err != nil {
+		p.res.AddErr(err)
+		p.res.Return(EXCEPTION)
+		p.stopped = !p.continueOnErr
+	}
+	p.res.alignMeta(len(p.res.ln.Notes()))
+	for i := start; i < len(p.res.nmeta); i++ {
+		p.res.nmeta[i].step = name
+	}
+	return p
+}
+
+// Result returns the accumulated Result
+func (p *Pipeline) Result() Result {
+	return p.res
+}
@@ -0,0 +1,59 @@
+package result
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestPipeline(t *testing.T) {
+	ok := func(msg string) func(*Result) error {
+		return func(r *Result) error {
+			r.AddInfo("%s", msg)
+			return nil
+		}
+	}
+	fail := func(r *Result) error {
+		r.AddWarning("partial")
+		return errors.New("failed")
+	}
+	tests := []struct {
+		name       string
+		continueOn bool
+		steps      []func(*Result) error
+		status     Status
+		want       []string
+		stepNames  []string
+	}{
+		{"all pass", false, []func(*Result) error{ok("a"), ok("b")}, OK,
+			[]string{"INF: a", "INF: b"}, []string{"s0", "s1"}},
+		{"stop on error", false, []func(*Result) error{ok("a"), fail, ok("c")}, EXCEPTION,
+			[]string{"INF: a", "WRN: partial", "ERR: failed"}, []string{"s0", "s1", "s1"}},
+		{"continue on error", true, []func(*Result) error{fail, ok("b")}, EXCEPTION,
+			[]string{"WRN: partial", "ERR: failed", "INF: b"}, []string{"s0", "s0", "s1"}},
+		{"nil step", false, []func(*Result) error{nil, ok("b")}, OK,
+			[]string{"INF: b"}, []string{"s1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewPipeline(WithStatus(OK)).ContinueOnError(tt.continueOn)
+			for i, s := range tt.steps {
+				p.Step("s"+string(rune('0'+i)), s)
+			}
+			r := p.Result()
+			if r.Status != string(tt.status) {
+				t.Fatalf("got status %s", r.Status)
+			}
+			if !reflect.DeepEqual(r.Messages, tt.want) {
+				t.Fatalf("got %q, want %q", r.Messages, tt.want)
+			}
+			var steps []string
+			for _, m := range r.StructuredMessages() {
+				steps = append(steps, m.Step)
+			}
+			if !reflect.DeepEqual(steps, tt.stepNames) {
+				t.Fatalf("got steps %q, want %q", steps, tt.stepNames)
+			}
+		})
+	}
+}
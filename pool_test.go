@@ -0,0 +1,111 @@
+package result
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// recycled returns a Result that was used, recycled and initialized again,
+// as GetResult does after PutResult, and the first element of its former messages
+func recycled() (*Result, *string) {
+	r := InitResult(WithPreallocNotes(8), WithEscalationThreshold(1))
+	r.AddInfo("first")
+	r.AddInfoOnce("k", "once")
+	r.AddGroupedError("g", "grouped")
+	r.AddEscalatingWarning("w", "warn")
+	r.AddErr(fmt.Errorf("boom"))
+	msgs := &r.Messages[0]
+	r.recycle()
+	r.init(1, WithPreallocNotes(4))
+	return &r, msgs
+}
+
+func TestPoolReuse(t *testing.T) {
+	tests := []struct {
+		name  string
+		check func(r *Result, msgs *string) error
+	}{
+		{"messages", func(r *Result, msgs *string) error {
+			if len(r.Messages) != 0 || len(r.ln.Notes()) != 0 || len(r.nmeta) != 0 {
+				return fmt.Errorf("got %v", r.Messages)
+			}
+			return nil
+		}},
+		{"storage is reused", func(r *Result, msgs *string) error {
+			if cap(r.Messages) == 0 || &r.Messages[:1][0] != msgs {
+				return fmt.Errorf("messages storage not reused")
+			}
+			return nil
+		}},
+		{"grouped errors", func(r *Result, msgs *string) error {
+			if g := r.GroupedErrors(); len(g) != 0 {
+				return fmt.Errorf("got %v", g)
+			}
+			return nil
+		}},
+		{"once keys", func(r *Result, msgs *string) error {
+			r.AddInfoOnce("k", "once")
+			if len(r.Messages) != 1 {
+				return fmt.Errorf("once key kept: %v", r.Messages)
+			}
+			return nil
+		}},
+		{"escalation counts", func(r *Result, msgs *string) error {
+			r.AddEscalatingWarning("w", "warn")
+			if r.HasErrors() {
+				return fmt.Errorf("escalation count kept: %v", r.Messages)
+			}
+			return nil
+		}},
+		{"errors", func(r *Result, msgs *string) error {
+			if len(r.errs) != 0 {
+				return fmt.Errorf("errors kept: %v", r.errs)
+			}
+			return nil
+		}},
+		{"options", func(r *Result, msgs *string) error {
+			if r.escThreshold != 0 || r.prealloc != 4 {
+				return fmt.Errorf("options kept")
+			}
+			return nil
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, msgs := recycled()
+			if err := tt.check(r, msgs); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestPoolConcurrent(t *testing.T) {
+	var wg sync.WaitGroup
+	errc := make(chan error, 64)
+	for g := range 16 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 200 {
+				r := GetResult(WithStatus(OK))
+				msg := fmt.Sprintf("g%d-%d", g, i)
+				r.AddInfoOnce(msg, "%s", msg)
+				r.AddGroupedError(msg, "%s", msg)
+				r.Meta = map[string]any{"id": msg}
+				want := []string{"INF: " + msg, "ERR: " + msg}
+				if !reflect.DeepEqual(r.Messages, want) || len(r.GroupedErrors()) != 1 {
+					errc <- fmt.Errorf("got %v, want %v", r.Messages, want)
+				}
+				PutResult(r)
+			}
+		}()
+	}
+	wg.Wait()
+	close(errc)
+	for err := range errc {
+		t.Error(err)
+	}
+}
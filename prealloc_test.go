@@ -0,0 +1,81 @@
+package result
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPreallocParity(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []InitResultOption
+		run  func(r *Result)
+	}{
+		{"adds", nil, func(r *Result) {
+			r.AddInfo("one")
+			r.AddWarning("two %d", 2)
+			r.AddError("three")
+			r.AddRawMsg("raw")
+		}},
+		{"drain", nil, func(r *Result) {
+			r.AddInfo("one")
+			r.DrainMessages()
+			r.AddInfo("two")
+		}},
+		{"redact", nil, func(r *Result) {
+			r.AddInfo("mail a@b.com")
+			r.RedactMessages(EmailPattern)
+			r.AddInfo("after")
+		}},
+		{"promote", nil, func(r *Result) {
+			r.AddWarning("w")
+			r.PromoteWarningsToErrors()
+			r.AddInfo("after")
+		}},
+		{"min severity", []InitResultOption{WithMinSeverity(SeverityWarning)}, func(r *Result) {
+			r.AddInfo("hidden")
+			r.AddWarning("shown")
+		}},
+		{"formatter", []InitResultOption{WithMessageFormatter(func(m Message) string { return "<" + m.Message + ">" })}, func(r *Result) {
+			r.AddInfo("one")
+			r.AddError("two")
+		}},
+		{"stuff", nil, func(r *Result) {
+			o := InitResult()
+			o.AddError("other")
+			r.AddInfo("one")
+			r.Stuff(o)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := InitResult(tt.opts...)
+			tt.run(&want)
+			got := InitResult(append([]InitResultOption{WithPreallocNotes(4)}, tt.opts...)...)
+			tt.run(&got)
+			if !reflect.DeepEqual(got.Messages, want.Messages) {
+				t.Fatalf("got %q, want %q", got.Messages, want.Messages)
+			}
+		})
+	}
+}
+
+func BenchmarkAdd(b *testing.B) {
+	b.ReportAllocs()
+	for range b.N {
+		r := InitResult(WithStatus(OK))
+		for range 8 {
+			r.AddInfo("%d rows affected", 10)
+		}
+	}
+}
+
+func BenchmarkAddPrealloc(b *testing.B) {
+	b.ReportAllocs()
+	for range b.N {
+		r := InitResult(WithStatus(OK), WithPreallocNotes(8))
+		for range 8 {
+			r.AddInfo("%d rows affected", 10)
+		}
+	}
+}
@@ -0,0 +1,20 @@
+package result
+
+// SetProgress sets the progress as the ratio of done to total, clamped to 0.0 to 1.0.
+// The progress is 0.0 if total is 0.
+func (r *Result) SetProgress(done, total int64) {
+	p := 0.0
+	if total != 0 {
+		p = float64(done) / float64(total)
+	}
+	p = min(max(p, 0), 1)
+	r.ProgressRatio = &p
+}
+
+// Progress returns the progress from 0.0 to 1.0, or 0.0 if not set
+func (r *Result) Progress() float64 {
+	if r.ProgressRatio == nil {
+		return 0
+	}
+	return *r.ProgressRatio
+}
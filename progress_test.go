@@ -0,0 +1,29 @@
+package result
+
+import "testing"
+
+func TestSetProgress(t *testing.T) {
+	tests := []struct {
+		name        string
+		done, total int64
+		want        float64
+	}{
+		{"half", 5, 10, 0.5},
+		{"done", 10, 10, 1},
+		{"over", 12, 10, 1},
+		{"negative", -1, 10, 0},
+		{"no total", 3, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := InitResult(WithStatus(OK))
+			if r.Progress() != 0 || r.ProgressRatio != nil {
+				t.Fatal("progress set before SetProgress")
+			}
+			r.SetProgress(tt.done, tt.total)
+			if got := r.Progress(); got != tt.want || *r.ProgressRatio != tt.want {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
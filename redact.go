@@ -0,0 +1,111 @@
+package result
+
+import (
+	"errors"
+	"regexp"
+
+	l "github.com/stdutil/log"
+)
+
+// Built-in patterns for RedactMessages.
+// Matches of CreditCardPattern are only redacted if their digits pass the Luhn
+// check, so that timestamps, order ids and other long numbers are kept.
+var (
+	EmailPattern      = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+	CreditCardPattern = regexp.MustCompile(`\b(?:\d[ \-]?){12,18}\d\b`)
+)
+
+// RedactMessages replaces the matches of the rules with *** in all messages,
+// including the grouped errors, the errors returned by ToError and the messages
+// of the Cause. An error with a match is replaced by an error with the redacted
+// text, so errors.Is and errors.As no longer find it. The lazy messages are
+// produced first, and the ones below the minimum severity are redacted when
+// they are produced.
+// The number of messages is preserved, and redacting again has no effect.
+func (r *Result) RedactMessages(rules ...*regexp.Regexp) {
+	if len(rules) == 0 {
+		return
+	}
+	redact := func(s string) string {
+		for _, re := range rules {
+			switch re {
+			case nil:
+			case CreditCardPattern:
+				s = re.ReplaceAllStringFunc(s, redactCard)
+			default:
+				s = re.ReplaceAllString(s, "***")
+			}
+		}
+		return s
+	}
+	r.resolveLazy()
+	nts := append([]l.LogInfo(nil), r.ln.Notes()...)
+	for i := range nts {
+		nts[i].Message = redact(nts[i].Message)
+	}
+	r.ln.Clear()
+	r.ln.Append(nts...)
+	r.nmeta = append([]noteMeta(nil), r.nmeta...)
+	for i := range r.nmeta {
+		if r.nmeta[i].original != "" {
+			r.nmeta[i].original = redact(r.nmeta[i].original)
+		}
+		if r.nmeta[i].groupMsg != "" {
+			r.nmeta[i].groupMsg = redact(r.nmeta[i].groupMsg)
+		}
+		if lm := r.nmeta[i].lazy; lm != nil {
+			r.nmeta[i].lazy = &lazyMsg{fn: func() string { return redact(lm.get()) }}
+		}
+	}
+	if r.groups != nil {
+		groups := make(map[string][]string, len(r.groups))
+		for k, msgs := range r.groups {
+			for _, m := range msgs {
+				groups[k] = append(groups[k], redact(m))
+			}
+		}
+		r.groups = groups
+	}
+	if len(r.errs) > 0 {
+		errs := make([]error, len(r.errs))
+		for i, err := range r.errs {
+			errs[i] = err
+			if msg := redact(err.Error()); msg != err.Error() {
+				errs[i] = errors.New(msg)
+			}
+		}
+		r.errs = errs
+	}
+	if r.Cause != nil {
+		c := r.Cause.clone(map[*Result]bool{}) // the cause may be shared with copies
+		c.RedactMessages(rules...)
+		r.Cause = &c
+	}
+	r.rendered = 0 // notes changed in place
+	r.updateMessage()
+}
+
+// redactCard returns *** for a card number that passes the Luhn check,
+// or the number as is
+func redactCard(s string) string {
+	sum, n := 0, 0
+	for i := len(s) - 1; i >= 0; i-- {
+		c := s[i]
+		if c < '0' || c > '9' {
+			continue
+		}
+		d := int(c - '0')
+		if n%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		n++
+	}
+	if sum%10 != 0 {
+		return s
+	}
+	return "***"
+}
@@ -0,0 +1,89 @@
+package result
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+)
+
+func TestRedactMessages(t *testing.T) {
+	tests := []struct {
+		name  string
+		msg   string
+		rules []*regexp.Regexp
+		want  string
+	}{
+		{"email", "contact ann.lee+x@mail.example.com now", []*regexp.Regexp{EmailPattern}, "INF: contact *** now"},
+		{"card", "paid with 4111 1111 1111 1111", []*regexp.Regexp{CreditCardPattern}, "INF: paid with ***"},
+		{"card with dashes", "card 5500-0000-0000-0004 declined", []*regexp.Regexp{CreditCardPattern}, "INF: card *** declined"},
+		{"timestamp kept", "at 20240115123045123", []*regexp.Regexp{CreditCardPattern}, "INF: at 20240115123045123"},
+		{"order id kept", "order 1234567890123456 shipped", []*regexp.Regexp{CreditCardPattern}, "INF: order 1234567890123456 shipped"},
+		{"short number kept", "code 123456", []*regexp.Regexp{CreditCardPattern}, "INF: code 123456"},
+		{"both", "a@b.io paid 4111111111111111", []*regexp.Regexp{EmailPattern, CreditCardPattern}, "INF: *** paid ***"},
+		{"custom rule", "token abc123", []*regexp.Regexp{regexp.MustCompile(`abc\d+`)}, "INF: token ***"},
+		{"nil rule", "a@b.io", []*regexp.Regexp{nil}, "INF: a@b.io"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := InitResult(WithStatus(OK))
+			r.AddInfo("%s", tt.msg)
+			r.RedactMessages(tt.rules...)
+			if len(r.Messages) != 1 || r.Messages[0] != tt.want {
+				t.Fatalf("got %q, want %q", r.Messages, tt.want)
+			}
+			r.RedactMessages(tt.rules...)
+			if r.Messages[0] != tt.want {
+				t.Fatalf("redacting again gave %q", r.Messages[0])
+			}
+		})
+	}
+}
+
+func TestRedactLazyMessages(t *testing.T) {
+	r := InitResult()
+	r.AddInfoLazy(func() string { return "mail a@b.com" })
+	r.RedactMessages(EmailPattern)
+	if want := "INF: mail ***"; r.MessagesToString() != want {
+		t.Fatalf("got %q, want %q", r.MessagesToString(), want)
+	}
+
+	// a lazy message below the minimum severity is redacted when it is produced elsewhere
+	filtered := InitResult(WithMinSeverity(SeverityWarning))
+	filtered.AddInfoLazy(func() string { return "mail a@b.com" })
+	filtered.RedactMessages(EmailPattern)
+	all := InitResult()
+	all.Stuff(filtered)
+	if want := "INF: mail ***"; all.MessagesToString() != want {
+		t.Fatalf("got %q, want %q", all.MessagesToString(), want)
+	}
+}
+
+func TestRedactErrorsAndCause(t *testing.T) {
+	kept := errors.New("disk full")
+	up := InitResult(WithStatus(EXCEPTION))
+	up.AddErr(errors.New("upstream x@y.com down"))
+	r := InitResult(WithStatus(EXCEPTION))
+	r.AddErr(errors.New("user x@y.com failed"))
+	r.AddErr(kept)
+	r.SetCause(up)
+	c := r
+	r.RedactMessages(EmailPattern)
+	if got, want := r.ToError().Error(), "user *** failed\ndisk full"; got != want {
+		t.Fatalf("got error %q, want %q", got, want)
+	}
+	if !errors.Is(r.ToError(), kept) {
+		t.Fatal("error without a match was replaced")
+	}
+	if got := r.Cause.MessagesToString(); got != "ERR: upstream *** down" {
+		t.Fatalf("got cause %q", got)
+	}
+	if got := r.Cause.ToError().Error(); got != "upstream *** down" {
+		t.Fatalf("got cause error %q", got)
+	}
+	if got := c.Cause.MessagesToString(); got != "ERR: upstream x@y.com down" {
+		t.Fatalf("copy changed to %q", got)
+	}
+	if got := c.ToError().Error(); got != "user x@y.com failed\ndisk full" {
+		t.Fatalf("copy error changed to %q", got)
+	}
+}
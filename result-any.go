@@ -1,5 +1,7 @@
 package result
 
+import "time"
+
 // AddInfo adds an information message and returns itself
 func (r *ResultAny[T]) AddInfo(fmtMsg string, a ...interface{}) ResultAny[T] {
 	r.Result.AddInfo(fmtMsg, a...)
@@ -82,3 +84,194 @@ func (r *ResultAny[T]) Return(status Status) ResultAny[T] {
 		Data:   r.Data,
 	}
 }
+
+// AddInfoText adds an information message as is, without formatting, and returns itself
+func (r *ResultAny[T]) AddInfoText(msg string) ResultAny[T] {
+	r.Result.AddInfoText(msg)
+	return ResultAny[T]{
+		Result: r.Result,
+		Data:   r.Data,
+	}
+}
+
+// AddWarningText adds a warning message as is, without formatting, and returns itself
+func (r *ResultAny[T]) AddWarningText(msg string) ResultAny[T] {
+	r.Result.AddWarningText(msg)
+	return ResultAny[T]{
+		Result: r.Result,
+		Data:   r.Data,
+	}
+}
+
+// AddErrorText adds an error message as is, without formatting, and returns itself
+func (r *ResultAny[T]) AddErrorText(msg string) ResultAny[T] {
+	r.Result.AddErrorText(msg)
+	return ResultAny[T]{
+		Result: r.Result,
+		Data:   r.Data,
+	}
+}
+
+// AddSuccessText adds a success message as is, without formatting, and returns itself
+func (r *ResultAny[T]) AddSuccessText(msg string) ResultAny[T] {
+	r.Result.AddSuccessText(msg)
+	return ResultAny[T]{
+		Result: r.Result,
+		Data:   r.Data,
+	}
+}
+
+// AddInfoOnce adds an information message only if the key was not used before.
+// It returns itself.
+func (r *ResultAny[T]) AddInfoOnce(key, fmtMsg string, a ...any) ResultAny[T] {
+	r.Result.AddInfoOnce(key, fmtMsg, a...)
+	return ResultAny[T]{
+		Result: r.Result,
+		Data:   r.Data,
+	}
+}
+
+// AddWarningOnce adds a warning message only if the key was not used before.
+// It returns itself.
+func (r *ResultAny[T]) AddWarningOnce(key, fmtMsg string, a ...any) ResultAny[T] {
+	r.Result.AddWarningOnce(key, fmtMsg, a...)
+	return ResultAny[T]{
+		Result: r.Result,
+		Data:   r.Data,
+	}
+}
+
+// AddErrorOnce adds an error message only if the key was not used before.
+// It returns itself.
+func (r *ResultAny[T]) AddErrorOnce(key, fmtMsg string, a ...any) ResultAny[T] {
+	r.Result.AddErrorOnce(key, fmtMsg, a...)
+	return ResultAny[T]{
+		Result: r.Result,
+		Data:   r.Data,
+	}
+}
+
+// AddTemplatedError adds an error message rendered from a template with named
+// placeholders resolved from data, and returns itself
+func (r *ResultAny[T]) AddTemplatedError(tmpl string, data map[string]any) ResultAny[T] {
+	r.Result.AddTemplatedError(tmpl, data)
+	return ResultAny[T]{
+		Result: r.Result,
+		Data:   r.Data,
+	}
+}
+
+// AddFieldError adds an error message for a field and returns itself
+func (r *ResultAny[T]) AddFieldError(field, fmtMsg string, a ...any) ResultAny[T] {
+	r.Result.AddFieldError(field, fmtMsg, a...)
+	return ResultAny[T]{
+		Result: r.Result,
+		Data:   r.Data,
+	}
+}
+
+// AddInfoLazy adds an information message produced by fn when it is rendered, and returns itself
+func (r *ResultAny[T]) AddInfoLazy(fn func() string) ResultAny[T] {
+	r.Result.AddInfoLazy(fn)
+	return ResultAny[T]{
+		Result: r.Result,
+		Data:   r.Data,
+	}
+}
+
+// AddWarningLazy adds a warning message produced by fn when it is rendered, and returns itself
+func (r *ResultAny[T]) AddWarningLazy(fn func() string) ResultAny[T] {
+	r.Result.AddWarningLazy(fn)
+	return ResultAny[T]{
+		Result: r.Result,
+		Data:   r.Data,
+	}
+}
+
+// AddErrorLazy adds an error message produced by fn when it is rendered, and returns itself
+func (r *ResultAny[T]) AddErrorLazy(fn func() string) ResultAny[T] {
+	r.Result.AddErrorLazy(fn)
+	return ResultAny[T]{
+		Result: r.Result,
+		Data:   r.Data,
+	}
+}
+
+// AddErrorWith adds a formatted error message with attributes and returns itself
+func (r *ResultAny[T]) AddErrorWith(attrs map[string]any, fmtMsg string, a ...any) ResultAny[T] {
+	r.Result.AddErrorWith(attrs, fmtMsg, a...)
+	return ResultAny[T]{
+		Result: r.Result,
+		Data:   r.Data,
+	}
+}
+
+// AddGroupedError adds a formatted error message to a group and returns itself
+func (r *ResultAny[T]) AddGroupedError(group, fmtMsg string, a ...any) ResultAny[T] {
+	r.Result.AddGroupedError(group, fmtMsg, a...)
+	return ResultAny[T]{
+		Result: r.Result,
+		Data:   r.Data,
+	}
+}
+
+// AddEscalatingWarning adds a formatted warning message that becomes an error once
+// the occurrences of the key exceed the escalation threshold, and returns itself
+func (r *ResultAny[T]) AddEscalatingWarning(key, fmtMsg string, a ...any) ResultAny[T] {
+	r.Result.AddEscalatingWarning(key, fmtMsg, a...)
+	return ResultAny[T]{
+		Result: r.Result,
+		Data:   r.Data,
+	}
+}
+
+// AddTimeout adds an error for an operation that timed out, sets the status to
+// TIMEOUT, marks the result as retryable and returns itself
+func (r *ResultAny[T]) AddTimeout(operation string, elapsed time.Duration) ResultAny[T] {
+	r.Result.AddTimeout(operation, elapsed)
+	return ResultAny[T]{
+		Result: r.Result,
+		Data:   r.Data,
+	}
+}
+
+// FromTuple sets the data and, if err is not nil, adds the error and sets
+// the status to EXCEPTION. The data is always set. It returns itself.
+func (r *ResultAny[T]) FromTuple(data T, err error) ResultAny[T] {
+	r.Data = data
+	if err != nil {
+		r.Result.AddErr(err)
+		r.Result.Return(EXCEPTION)
+	}
+	return ResultAny[T]{
+		Result: r.Result,
+		Data:   r.Data,
+	}
+}
+
+// Zip combines two ResultAny values into a pair. The messages of both are merged,
+// and the status is the more severe of the two, preferring the first on a tie.
+// The pair is set only when both statuses are OK, VALID or YES. If either side
+// failed, the data is the zero value.
+func Zip[A, B any](ra ResultAny[A], rb ResultAny[B]) ResultAny[struct {
+	First  A
+	Second B
+}] {
+	res := ResultAny[struct {
+		First  A
+		Second B
+	}]{}
+	res.Result.init(2)
+	res.Result.Stuff(ra.Result)
+	res.Result.Stuff(rb.Result)
+	sa, sb := Status(ra.Status), Status(rb.Status)
+	res.Result.Status = ra.Status
+	if sb.Severity() > sa.Severity() {
+		res.Result.Status = rb.Status
+	}
+	if sa.Severity() == SeverityInfo && sb.Severity() == SeverityInfo {
+		res.Data.First = ra.Data
+		res.Data.Second = rb.Data
+	}
+	return res
+}
@@ -0,0 +1,177 @@
+package result
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// wrapperCases are the Add methods of Result wrapped by ResultAny and PagedResult,
+// applied to the embedded Result, with the messages they add to an OK Result
+var wrapperCases = []struct {
+	name string
+	want []string
+}{
+	{"AddInfoText", []string{"INF: 100% %s"}},
+	{"AddWarningText", []string{"WRN: 100% %s"}},
+	{"AddErrorText", []string{"ERR: 100% %s"}},
+	{"AddSuccessText", []string{"SUC: 100% %s"}},
+	{"AddInfoOnce", []string{"INF: once 1"}},
+	{"AddWarningOnce", []string{"WRN: once 1"}},
+	{"AddErrorOnce", []string{"ERR: once 1"}},
+	{"AddTemplatedError", []string{"ERR: User ann not found"}},
+	{"AddFieldError", []string{"ERR: email is required"}},
+	{"AddInfoLazy", []string{"INF: lazy"}},
+	{"AddWarningLazy", []string{"WRN: lazy"}},
+	{"AddErrorLazy", []string{"ERR: lazy"}},
+	{"AddErrorWith", []string{"ERR: bad row 4"}},
+	{"AddGroupedError", []string{"ERR: card declined"}},
+	{"AddEscalatingWarning", []string{"WRN: slow k"}},
+	{"AddTimeout", []string{"ERR: fetch timed out after 2s"}},
+}
+
+// callWrapper calls the wrapper named name twice on v, which is a *ResultAny[T]
+// or a *PagedResult[T], and returns the value returned by the last call
+func callWrapper(t *testing.T, v any, name string) reflect.Value {
+	t.Helper()
+	m := reflect.ValueOf(v).MethodByName(name)
+	if !m.IsValid() {
+		t.Fatalf("%T has no method %s", v, name)
+	}
+	var args []any
+	switch name {
+	case "AddInfoText", "AddWarningText", "AddErrorText", "AddSuccessText":
+		args = []any{"100% %s"}
+	case "AddInfoOnce", "AddWarningOnce", "AddErrorOnce":
+		args = []any{"k", "once %d", 1}
+	case "AddTemplatedError":
+		args = []any{"User {user} not found", map[string]any{"user": "ann"}}
+	case "AddFieldError":
+		args = []any{"email", "%s is required", "email"}
+	case "AddErrorWith":
+		args = []any{map[string]any{"row": 4}, "bad row %d", 4}
+	case "AddGroupedError":
+		args = []any{"billing", "card declined"}
+	case "AddEscalatingWarning":
+		args = []any{"k", "slow %s", "k"}
+	case "AddTimeout":
+		args = []any{"fetch", 2 * time.Second}
+	default:
+		args = []any{func() string { return "lazy" }}
+	}
+	var out []reflect.Value
+	for i := 0; i < 2; i++ {
+		in := make([]reflect.Value, len(args))
+		for j, a := range args {
+			in[j] = reflect.ValueOf(a)
+		}
+		out = m.Call(in)
+		if !strings.HasSuffix(name, "Once") {
+			break // only the once methods are called twice
+		}
+	}
+	return out[0]
+}
+
+func TestResultAnyWrappers(t *testing.T) {
+	for _, tt := range wrapperCases {
+		t.Run(tt.name, func(t *testing.T) {
+			r := ResultAny[int]{Result: InitResult(WithStatus(OK)), Data: 7}
+			got := callWrapper(t, &r, tt.name).Interface().(ResultAny[int])
+			if got.Data != 7 {
+				t.Fatalf("got data %d", got.Data)
+			}
+			if msgs := got.StructuredMessages(); len(msgs) != len(tt.want) {
+				t.Fatalf("got %v", msgs)
+			}
+			if r.MessagesToString(); !reflect.DeepEqual(r.Messages, tt.want) {
+				t.Fatalf("got %q, want %q", r.Messages, tt.want)
+			}
+		})
+	}
+}
+
+func TestPagedResultWrappers(t *testing.T) {
+	for _, tt := range wrapperCases {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewPagedResult([]int{1, 2}, 2, 2, 5)
+			got := callWrapper(t, &r, tt.name).Interface().(PagedResult[int])
+			if !reflect.DeepEqual(got.Items, []int{1, 2}) || got.Total != 5 ||
+				got.CurrentPage() != 2 || got.TotalPages() != 3 || got.PageSize() != 2 {
+				t.Fatalf("got %+v", got)
+			}
+			if r.MessagesToString(); !reflect.DeepEqual(r.Messages, tt.want) {
+				t.Fatalf("got %q, want %q", r.Messages, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromTuple(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   int
+		err    error
+		status Status
+		want   []string
+	}{
+		{"data", 5, nil, OK, []string{}},
+		{"error keeps data", 5, errors.New("partial"), EXCEPTION, []string{"ERR: partial"}},
+		{"zero data and error", 0, errors.New("failed"), EXCEPTION, []string{"ERR: failed"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := ResultAny[int]{Result: InitResult(WithStatus(OK))}
+			got := r.FromTuple(tt.data, tt.err)
+			if got.Data != tt.data || r.Data != tt.data {
+				t.Fatalf("got data %d", got.Data)
+			}
+			if got.Status != string(tt.status) || !reflect.DeepEqual(got.Messages, tt.want) {
+				t.Fatalf("got status %s, messages %q", got.Status, got.Messages)
+			}
+			if tt.err != nil && !errors.Is(got.ToError(), tt.err) {
+				t.Fatalf("error not retained: %v", got.ToError())
+			}
+		})
+	}
+}
+
+func TestZip(t *testing.T) {
+	mk := func(s Status, msg string) ResultAny[string] {
+		r := ResultAny[string]{Result: InitResult(WithStatus(s)), Data: string(s)}
+		r.AddInfo("%s", msg)
+		return r
+	}
+	tests := []struct {
+		name   string
+		a, b   ResultAny[string]
+		status Status
+		pair   bool
+	}{
+		{"both ok", mk(OK, "a"), mk(VALID, "b"), OK, true},
+		{"second failed", mk(OK, "a"), mk(INVALID, "b"), INVALID, false},
+		{"first failed", mk(EXCEPTION, "a"), mk(OK, "b"), EXCEPTION, false},
+		{"tie prefers first", mk(INVALID, "a"), mk(EXCEPTION, "b"), INVALID, false},
+		{"warning status", mk(OK, "a"), mk("RATE_LIMITED", "b"), "RATE_LIMITED", false},
+	}
+	RegisterStatus("RATE_LIMITED", SeverityWarning, http.StatusTooManyRequests)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			z := Zip(tt.a, tt.b)
+			if z.Status != string(tt.status) {
+				t.Fatalf("got status %s, want %s", z.Status, tt.status)
+			}
+			if want := []string{"INF: a", "INF: b"}; !reflect.DeepEqual(z.Messages, want) {
+				t.Fatalf("got %q", z.Messages)
+			}
+			paired := z.Data.First == tt.a.Data && z.Data.Second == tt.b.Data
+			zero := z.Data.First == "" && z.Data.Second == ""
+			if (tt.pair && !paired) || (!tt.pair && !zero) {
+				t.Fatalf("got data %+v", z.Data)
+			}
+		})
+	}
+}
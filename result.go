@@ -5,13 +5,23 @@
 package result
 
 import (
+	"database/sql"
+	"errors"
 	"fmt"
 	"runtime"
 	"strings"
+	"time"
 
 	l "github.com/stdutil/log"
 )
 
+// DefaultPrefix is the prefix of new results when WithPrefix is not given.
+// WithPrefix and SetPrefix override it, even with an empty prefix.
+var DefaultPrefix string
+
+// GlobalHooks are called by the Add methods of all results, unless overridden by WithHooks
+var GlobalHooks Hooks
+
 // Status items
 const (
 	OK        Status = `OK`
@@ -20,63 +30,154 @@ const (
 	INVALID   Status = `INVALID`
 	YES       Status = `YES`
 	NO        Status = `NO`
+	TIMEOUT   Status = `TIMEOUT` // Registered with error severity and 504 Gateway Timeout
 )
 
 // InitResult - initialize result for API query. This is the recommended initialization of this object.
 // The variadic arguments of InitResultOption will modify default status.
 // Depending on the current status (default is EXCEPTION), the message type is automatically set to that type
 func InitResult(opts ...InitResultOption) Result {
-	res := Result{
+	res := Result{}
+	res.init(2, opts...)
+	return res
+}
+
+// init initializes the result with the options. The skip argument is the
+// number of stack frames to ascend to auto-detect the calling operation.
+func (r *Result) init(skip int, opts ...InitResultOption) {
+	sp := r.spare // storage of a pooled Result
+	*r = Result{
 		Status:  string(EXCEPTION),
 		ln:      l.Log{},
 		osIsWin: runtime.GOOS == "windows",
 	}
-	res.Messages = make([]string, 0)
-	irp := InitResultParam{}
+	r.Messages = make([]string, 0)
+	irp := InitResultParam{
+		Prefix: DefaultPrefix,
+	}
+	var errs []error
 	for _, o := range opts {
 		if o == nil {
 			continue
 		}
-		o(&irp)
+		if err := o(&irp); err != nil {
+			errs = append(errs, err)
+		}
 	}
+	r.initErr = errors.Join(errs...)
 	if irp.Status != "" {
-		res.Status = string(irp.Status)
+		r.Status = string(irp.Status)
+	}
+	r.SetPrefix(irp.Prefix)
+	r.eventVerb = irp.EventVerb
+	r.strictTmpl = irp.StrictTemplates
+	r.title = irp.Title
+	r.titleDetail = irp.TitleDetail
+	r.escThreshold = irp.EscalationThreshold
+	r.maxMsgLen = irp.MaxMessageLength
+	r.failFast = irp.FailFast
+	r.msgFormatter = irp.MessageFormatter
+	r.timeLoc = irp.TimeLocation
+	r.strictTrans = irp.StrictTransitions
+	r.warnAsErr = irp.WarningsAsErrors
+	r.zeroRowsWarn = irp.ZeroRowsAsWarning
+	r.zeroRowsStatus = irp.ZeroRowsStatus
+	r.scalarMsg = irp.ScalarSingleMessage
+	r.minSev = irp.MinSeverity
+	r.dedupMerge = irp.DedupOnMerge
+	r.groupFocus = irp.GroupFocus
+	r.successFlag = irp.SuccessFlag
+	r.inclErrType = irp.IncludeErrorType
+	r.useOperationInMsg = irp.OperationInAllMessages
+	r.opMsgFmt = irp.OperationMessageFormat
+	r.hooks = irp.Hooks
+	r.coerce = irp.CoerceStatus
+	r.clock = irp.Clock
+	r.fcFormatter = irp.FocusControlFormatter
+	if irp.PreallocNotes > 0 {
+		r.prealloc = irp.PreallocNotes
+		r.Messages = make([]string, 0, r.prealloc)
+		r.nmeta = make([]noteMeta, 0, r.prealloc)
+		r.scratch = make([]byte, 0, 128)
+	}
+	if sp != nil {
+		r.reuse(sp)
 	}
-	res.SetPrefix(irp.Prefix)
-	res.eventVerb = irp.EventVerb
-	res.initFc = irp.InitialFocusID // preserve initial focus control
-	res.SetFocusControl(res.initFc, false)
+	r.initFc = irp.InitialFocusID // preserve initial focus control
+	r.SetFocusControl(r.initFc, false)
 
 	// Auto-detect function that called this function
-	if pc, _, _, ok := runtime.Caller(1); ok {
-		if details := runtime.FuncForPC(pc); details != nil {
-			nm := details.Name()
-			if pos := strings.LastIndex(nm, `.`); pos != -1 {
-				nm = nm[pos+1:]
-			}
-			res.Operation = strings.ToLower(nm)
-			if res.eventVerb == "" {
-				res.eventVerb = res.Operation
-			}
+	if nm, ok := detectOperation(skip); ok {
+		r.Operation = nm
+		if r.eventVerb == "" {
+			r.eventVerb = r.Operation
 		}
 	}
 
 	if irp.Message != "" {
+		if irp.RawInitialMessage {
+			r.addNote(l.App, irp.Message, nil) // as is
+			return
+		}
 		msg := irp.Message
-		if irp.UseOperationInMsg && res.Operation != "" {
-			msg = fmt.Sprintf(" %s: %s", res.Operation, irp.Message)
+		if irp.UseOperationInMsg && !r.useOperationInMsg {
+			msg = r.formatOperation(msg) // the Add methods format it otherwise
 		}
 		switch irp.Status {
 		case OK, VALID, YES:
-			res.AddInfo("%s", msg)
+			r.AddInfo("%s", msg)
 		case EXCEPTION, INVALID, NO:
-			res.AddError("%s", msg)
+			r.AddError("%s", msg)
 		default:
-			res.AddRawMsg("%s", msg)
+			r.AddRawMsg("%s", r.operationMessage(msg))
 		}
 	}
+}
 
-	return res
+// DetectOperation re-runs the auto-detection of the operation at the skip depth,
+// where 0 is the function that called DetectOperation. It updates the Operation,
+// and the event verb if it was not set or was derived from the previous Operation.
+// It does nothing if the operation could not be resolved.
+func (r *Result) DetectOperation(skip int) {
+	nm, ok := detectOperation(skip + 1)
+	if !ok {
+		return
+	}
+	if r.eventVerb == "" || r.eventVerb == r.Operation {
+		r.eventVerb = nm
+	}
+	r.Operation = nm
+}
+
+// detectOperation returns the lower cased name of the function at the skip depth
+// from the function that called detectOperation
+func detectOperation(skip int) (string, bool) {
+	if skip < 0 {
+		return "", false
+	}
+	pc, _, _, ok := runtime.Caller(skip + 1)
+	if !ok {
+		return "", false
+	}
+	details := runtime.FuncForPC(pc)
+	if details == nil {
+		return "", false
+	}
+	nm := details.Name()
+	if pos := strings.LastIndex(nm, `.`); pos != -1 {
+		nm = nm[pos+1:]
+	}
+	if nm == "" {
+		return "", false
+	}
+	return strings.ToLower(nm), true
+}
+
+// InitError returns the errors of the options the Result was initialized with,
+// such as an invalid format of WithOperationMessageFormat. The options that
+// returned an error were not applied.
+func (r *Result) InitError() error {
+	return r.initErr
 }
 
 // MessageManager returns the internal message manager
@@ -84,8 +185,20 @@ func (r *Result) MessageManager() *l.Log {
 	return &r.ln
 }
 
-// Return sets the current status of a result
+// Return sets the current status of a result.
+// If allowed transitions are set and the transition is not allowed, a warning is added,
+// or the status is not changed and an error is added if strict transitions are on.
+// Changing the status clears the HTTP status code set by constructors such as NotFound.
 func (r *Result) Return(status Status) Result {
+	if !r.canTransition(status) {
+		if r.strictTrans {
+			return r.AddError("Status transition from %s to %s is not allowed", r.Status, status)
+		}
+		r.AddWarning("Status transition from %s to %s is not allowed", r.Status, status)
+	}
+	if r.Status != string(status) {
+		r.httpCode = 0
+	}
 	r.Status = string(status)
 	return *r
 }
@@ -120,79 +233,173 @@ func (r *Result) No() bool {
 	return r.Status == string(NO)
 }
 
-// AddInfo adds a formatted information message and returns itself
-func (r *Result) AddInfo(fmtMsg string, a ...any) Result {
-	msg := fmtMsg
-	if len(a) > 0 {
-		msg = fmt.Sprintf(fmtMsg, a...)
+// HasErrors returns true if there are error or fatal messages,
+// or warning messages when warnings are treated as errors.
+func (r *Result) HasErrors() bool {
+	for _, n := range r.ln.Notes() {
+		if r.noteSeverity(n.Type) >= SeverityError {
+			return true
+		}
 	}
-	if r.useOperationInMsg && r.Operation != "" {
-		msg = fmt.Sprintf(" %s: ", r.Operation) + msg
+	return false
+}
+
+// HasUserContent returns true if there are messages worth showing to a user,
+// which are the messages that are not application messages and are not below
+// the minimum severity set by WithMinSeverity.
+func (r *Result) HasUserContent() bool {
+	minSev := max(r.minSev, SeverityInfo)
+	for _, n := range r.ln.Notes() {
+		if n.Type != l.App && r.noteSeverity(n.Type) >= minSev {
+			return true
+		}
 	}
-	r.ln.AddInfo(msg)
-	r.updateMessage()
-	return *r
+	return false
+}
+
+// AddInfo adds a formatted information message and returns itself
+//
+// The message is only formatted when there are arguments, so a lone user input
+// is safe. User input must not be the format when there are arguments,
+// use AddInfoText instead.
+func (r *Result) AddInfo(fmtMsg string, a ...any) Result {
+	return r.add(l.Info, fmtMsg, a...)
 }
 
 // AddWarning adds a formatted warning message and returns itself
+//
+// Use AddWarningText to add user input as is.
 func (r *Result) AddWarning(fmtMsg string, a ...any) Result {
-	msg := fmtMsg
-	if len(a) > 0 {
-		msg = fmt.Sprintf(fmtMsg, a...)
-	}
-	if r.useOperationInMsg && r.Operation != "" {
-		msg = fmt.Sprintf(" %s: ", r.Operation) + msg
-	}
-	r.ln.AddWarning(msg)
-	r.updateMessage()
-	return *r
+	return r.add(l.Warn, fmtMsg, a...)
 }
 
 // AddError adds a formatted error message and returns itself
+//
+// Use AddErrorText to add user input as is.
 func (r *Result) AddError(fmtMsg string, a ...any) Result {
-	msg := fmtMsg
-	if len(a) > 0 {
-		msg = fmt.Sprintf(fmtMsg, a...)
+	return r.add(l.Error, fmtMsg, a...)
+}
+
+// AddInfoText adds an information message as is, without formatting, and returns itself
+func (r *Result) AddInfoText(msg string) Result {
+	return r.add(l.Info, "%s", msg)
+}
+
+// AddWarningText adds a warning message as is, without formatting, and returns itself
+func (r *Result) AddWarningText(msg string) Result {
+	return r.add(l.Warn, "%s", msg)
+}
+
+// AddErrorText adds an error message as is, without formatting, and returns itself
+func (r *Result) AddErrorText(msg string) Result {
+	return r.add(l.Error, "%s", msg)
+}
+
+// AddSuccessText adds a success message as is, without formatting, and returns itself
+func (r *Result) AddSuccessText(msg string) Result {
+	return r.add(l.Success, "%s", msg)
+}
+
+// AddErrorWith adds a formatted error message with attributes, such as a row id,
+// and returns itself. The attributes are copied and are returned by StructuredMessages.
+func (r *Result) AddErrorWith(attrs map[string]any, fmtMsg string, a ...any) Result {
+	n := len(r.ln.Notes())
+	r.add(l.Error, fmtMsg, a...)
+	if len(r.ln.Notes()) > n {
+		r.nmeta[len(r.nmeta)-1].attrs = copyAttrs(attrs)
 	}
-	if r.useOperationInMsg && r.Operation != "" {
-		msg = fmt.Sprintf(" %s: ", r.Operation) + msg
+	return *r
+}
+
+// AddEscalatingWarning adds a formatted warning message and returns itself.
+// The occurrences are counted by key, and once the count exceeds the threshold
+// set by WithEscalationThreshold, the message is added as an error and the status
+// is raised to EXCEPTION by Return. Without a threshold, the message is always a warning.
+func (r *Result) AddEscalatingWarning(key, fmtMsg string, a ...any) Result {
+	if r.escCounts == nil {
+		r.escCounts = make(map[string]int)
+	}
+	r.escCounts[key]++
+	if r.escThreshold > 0 && r.escCounts[key] > r.escThreshold {
+		r.Return(EXCEPTION)
+		return r.AddError(fmtMsg, a...)
 	}
-	r.ln.AddError(msg)
+	return r.AddWarning(fmtMsg, a...)
+}
+
+// PromoteWarningsToErrors changes all warning messages to error messages and
+// returns itself. If any was changed and the status is not already of error
+// severity, the status is raised to EXCEPTION by Return. Unlike WithWarningsAsErrors,
+// the type of the messages is changed.
+func (r *Result) PromoteWarningsToErrors() Result {
+	nts := append([]l.LogInfo(nil), r.ln.Notes()...)
+	n := 0
+	for i := range nts {
+		if nts[i].Type == l.Warn {
+			nts[i].Type = l.Error
+			n++
+		}
+	}
+	if n == 0 {
+		return *r
+	}
+	r.ln.Clear()
+	r.ln.Append(nts...)
+	r.rendered = 0 // notes changed in place
 	r.updateMessage()
+	if Status(r.Status).Severity() < SeverityError {
+		r.Return(EXCEPTION)
+	}
 	return *r
 }
 
 // AddErr adds a error-typed value and returns itself.
 func (r *Result) AddErr(err error) Result {
-	r.AddError("%s", err)
+	if err == nil {
+		return r.AddError("%s", err)
+	}
+	r.errs = append(r.errs, err)
+	if !r.inclErrType {
+		return r.AddError("%s", err)
+	}
+	// the outermost type of wrapped errors
+	typ := fmt.Sprintf("%T", err)
+	r.AddError("%s: %s", typ, err)
+	r.nmeta[len(r.nmeta)-1].errType = typ
 	return *r
 }
 
 // AddSuccess adds a formatted success message and returns itself
+//
+// Use AddSuccessText to add user input as is.
 func (r *Result) AddSuccess(fmtMsg string, a ...any) Result {
-	msg := fmtMsg
-	if len(a) > 0 {
-		msg = fmt.Sprintf(fmtMsg, a...)
-	}
-	if r.useOperationInMsg && r.Operation != "" {
-		msg = fmt.Sprintf(" %s: ", r.Operation) + msg
-	}
-	r.ln.AddSuccess(msg)
-	r.updateMessage()
-	return *r
+	return r.add(l.Success, fmtMsg, a...)
 }
 
 // AddRawMsg adds a formatted application message and returns itself
+//
+// Like AddInfo, the message is only formatted when there are arguments.
 func (r *Result) AddRawMsg(fmtMsg string, a ...any) Result {
 	msg := fmtMsg
 	if len(a) > 0 {
 		msg = fmt.Sprintf(fmtMsg, a...)
 	}
-	r.ln.AddAppMsg(msg)
-	r.updateMessage()
+	typ, msg := splitAppMsg(msg)
+	r.addNote(typ, msg, nil)
 	return *r
 }
 
+// splitAppMsg splits the type of an application message in the form of
+// TYPE[...]message, as the message manager does. The text in brackets is dropped.
+func splitAppMsg(msg string) (l.LogType, string) {
+	lpos := strings.Index(msg, "[")
+	rpos := strings.Index(msg, "]")
+	if lpos > -1 && rpos > -1 && lpos < rpos {
+		return l.LogType(msg[:lpos]), msg[rpos+1:]
+	}
+	return l.App, msg
+}
+
 // AddErrWithAlt adds an error-typed value, and an alternate error
 // message if the err happens to be nil. It returns itself.
 func (r *Result) AddErrWithAlt(err error, altMsg string, altMsgValues ...any) Result {
@@ -209,62 +416,54 @@ func (r *Result) AddErrWithAlt(err error, altMsg string, altMsgValues ...any) Re
 // And an alternative message if the Result is other than OK or VALID status.
 func (r *Result) AddErrorWithAlt(rs Result, altMsg string, altMsgValues ...any) Result {
 	if !(rs.OK() || rs.Valid()) {
-		for _, n := range rs.ln.Notes() {
-			r.ln.Append(n)
-		}
+		r.appendNotes(rs)
 		r.updateMessage()
 		return *r
 	}
 	if altMsg == "" {
 		return *r
 	}
-	r.ln.Append(
-		l.LogInfo{
-			Type:    l.Error,
-			Message: fmt.Sprintf(altMsg, altMsgValues...),
-			Prefix:  r.ln.Prefix,
-		})
-	r.updateMessage()
-	return *r
+	return r.add(l.Error, altMsg, altMsgValues...)
 }
 
 // AppendErr copies the messages of the Result parameter and append an error message
 func (r *Result) AppendErr(rs Result, err error) Result {
-	for _, n := range rs.ln.Notes() {
-		r.ln.Append(n)
-	}
+	r.appendNotes(rs)
 	return r.AddErr(err)
 }
 
 // AppendErrorf copies the messages of the Result parameter and append a formatted error message
 func (r *Result) AppendError(rs Result, fmtMsg string, a ...any) Result {
-	for _, n := range rs.ln.Notes() {
-		r.ln.Append(n)
-	}
+	r.appendNotes(rs)
 	return r.AddError(fmtMsg, a...)
 }
 
 // AppendInfof copies the messages of the Result parameter and append a formatted information message
 func (r *Result) AppendInfo(rs Result, fmtMsg string, a ...any) Result {
-	for _, n := range rs.ln.Notes() {
-		r.ln.Append(n)
-	}
+	r.appendNotes(rs)
 	return r.AddInfo(fmtMsg, a...)
 }
 
 // AppendWarning copies the messages of the Result parameter and append a formatted warning message
 func (r *Result) AppendWarning(rs Result, fmtMsg string, a ...any) Result {
-	for _, n := range rs.ln.Notes() {
-		r.ln.Append(n)
-	}
+	r.appendNotes(rs)
 	return r.AddWarning(fmtMsg, a...)
 }
 
 // Stuff adds or appends the messages of a Result.
 func (r *Result) Stuff(rs Result) Result {
-	for _, n := range rs.ln.Notes() {
-		r.ln.Append(n)
+	r.appendNotes(rs)
+	r.updateMessage()
+	return *r
+}
+
+// AbsorbByType appends only the notes of the given types from a Result,
+// preserving their order, and returns itself.
+func (r *Result) AbsorbByType(rs Result, types ...l.LogType) Result {
+	if len(types) == 0 {
+		return *r
 	}
+	r.appendNotes(rs, types...)
 	r.updateMessage()
 	return *r
 }
@@ -284,6 +483,7 @@ func (r *Result) EventID() string {
 
 // MessagesToString returns all messages in a string separated by carriage return and/or line feed
 func (r *Result) MessagesToString() string {
+	r.resolveLazy()
 	// The r.Messages might have been unmarshalled from result bytes so we should process.
 	if len(r.Messages) == 1 {
 		return r.Messages[0]
@@ -300,9 +500,43 @@ func (r *Result) MessagesToString() string {
 		}
 		return sb.String()
 	}
+	if r.minSev > SeverityNone {
+		return "" // all notes are below the minimum severity
+	}
 	return r.ln.ToString()
 }
 
+// Title returns a short title derived from the status, or the title set by WithTitle.
+// Registered statuses return the title set by RegisterStatusTitle.
+func (r *Result) Title() string {
+	if r.title != "" {
+		return r.title
+	}
+	switch Status(r.Status) {
+	case OK:
+		return "Success"
+	case EXCEPTION:
+		return "An error occurred"
+	case VALID:
+		return "Validation passed"
+	case INVALID:
+		return "Validation failed"
+	case YES:
+		return "Yes"
+	case NO:
+		return "No"
+	}
+	if si, ok := registeredStatus(Status(r.Status)); ok && si.title != "" {
+		return si.title
+	}
+	return r.Status
+}
+
+// Detail returns the messages joined by carriage return and/or line feed
+func (r *Result) Detail() string {
+	return strings.TrimRight(r.MessagesToString(), "\r\n")
+}
+
 // SetPrefix changes the prefix
 func (r *Result) SetPrefix(pfx string) {
 	r.ln.Prefix = pfx
@@ -330,20 +564,201 @@ func (r *Result) ResetFocusControl() {
 	r.FocusControl = &r.initFc
 }
 
+// Reset clears the messages, status and all fields of the Result.
+// Pointer fields are set to nil so that no data is retained.
+func (r *Result) Reset() {
+	*r = Result{
+		Messages: make([]string, 0),
+		osIsWin:  runtime.GOOS == "windows",
+	}
+}
+
+// AddTimeout adds an error for an operation that timed out, sets the status to
+// TIMEOUT, marks the Result as retryable and returns itself
+func (r *Result) AddTimeout(operation string, elapsed time.Duration) Result {
+	r.AddError("%s timed out after %s", operation, elapsed)
+	r.Retryable = true
+	return r.Return(TIMEOUT)
+}
+
 // RowsAffectedInfo - a function to simplify adding information for rows affected
+//
+// When WithZeroRowsAsWarning is on, no rows affected is added as a warning,
+// and the status is set to the one set by WithZeroRowsStatus, if any.
 func (r *Result) RowsAffectedInfo(rowsaff int64) {
 	if rowsaff != 0 {
 		r.AddInfo("%d rows affected", rowsaff)
-	} else {
+		return
+	}
+	if !r.zeroRowsWarn {
 		r.AddInfo("No rows affected")
+		return
+	}
+	r.AddWarning("No rows affected")
+	if r.zeroRowsStatus != "" {
+		r.Return(r.zeroRowsStatus)
 	}
 }
 
+// FromSQLResult sets the Result from the returns of a database/sql execution and returns itself.
+//
+// If err is not nil, the error is added and the status is set to EXCEPTION.
+// Otherwise, the status is set to OK, the rows affected are added as in RowsAffectedInfo,
+// and the last insert id is set. Drivers that do not support the rows affected or
+// the last insert id do not produce an error, the message or the id are left out.
+func (r *Result) FromSQLResult(res sql.Result, err error) Result {
+	if err != nil {
+		r.AddErr(err)
+		return r.Return(EXCEPTION)
+	}
+	r.Return(OK)
+	if res == nil {
+		return *r
+	}
+	if rows, err := res.RowsAffected(); err == nil {
+		r.RowsAffectedInfo(rows)
+	}
+	if id, err := res.LastInsertId(); err == nil {
+		r.LastInsertID = &id
+	}
+	return *r
+}
+
+// add formats the message, adds a note of the type and returns itself
+//
+// When fail-fast is on and an error was already added, non-error messages are skipped.
+// The status is not changed by fail-fast, it is still set by Return or the options.
+func (r *Result) add(typ l.LogType, fmtMsg string, a ...any) Result {
+	if r.failFast && r.noteSeverity(typ) < SeverityError && r.HasErrors() {
+		return *r
+	}
+	msg := fmtMsg
+	if len(a) > 0 {
+		msg = fmt.Sprintf(fmtMsg, a...)
+	}
+	r.addNote(typ, r.operationMessage(msg), nil)
+	return *r
+}
+
+// operationMessage formats the message with the Operation when it is used in all messages
+func (r *Result) operationMessage(msg string) string {
+	if !r.useOperationInMsg {
+		return msg
+	}
+	return r.formatOperation(msg)
+}
+
+// formatOperation formats the message with the Operation, if any
+func (r *Result) formatOperation(msg string) string {
+	if r.Operation == "" {
+		return msg
+	}
+	f := r.opMsgFmt
+	if f == "" {
+		f = " %s: %s"
+	}
+	return fmt.Sprintf(f, r.Operation, msg)
+}
+
+// addNote adds a note of the type and updates the messages.
+// If lazy is not nil, the message is produced by lazy when it is rendered.
+func (r *Result) addNote(typ l.LogType, msg string, lazy *lazyMsg) {
+	msg = strings.TrimSpace(msg)
+	meta := noteMeta{
+		time: r.now(),
+		op:   r.Operation,
+		lazy: lazy,
+	}
+	if tm, ok := truncateRunes(msg, r.maxMsgLen); ok {
+		meta.original = msg
+		msg = tm
+	}
+	before, after := r.activeHooks()
+	if before != nil {
+		before(r, Message{Type: typ, Prefix: r.ln.Prefix, Message: msg})
+	}
+	r.ln.Append(l.LogInfo{
+		Type:    typ,
+		Prefix:  r.ln.Prefix,
+		Message: msg,
+	})
+	r.seq++
+	meta.seq = r.seq
+	r.setLastMeta(meta)
+	r.updateMessage()
+	if after != nil {
+		i := len(r.ln.Notes()) - 1
+		after(r, r.toMessage(i, r.ln.Notes()[i]))
+	}
+}
+
+// activeHooks returns the hooks of the Result, or the global hooks if not set
+func (r *Result) activeHooks() (before, after func(r *Result, m Message)) {
+	before, after = GlobalHooks.BeforeAdd, GlobalHooks.AfterAdd
+	if r.hooks.BeforeAdd != nil {
+		before = r.hooks.BeforeAdd
+	}
+	if r.hooks.AfterAdd != nil {
+		after = r.hooks.AfterAdd
+	}
+	return
+}
+
 func (r *Result) updateMessage() {
 	// get current notes to update the messages array
 	nts := r.ln.Notes()
+	if r.prealloc > 0 {
+		r.updateMessagePrealloc(nts)
+		return
+	}
 	r.Messages = make([]string, 0, len(nts))
-	for _, n := range nts {
-		r.Messages = append(r.Messages, n.ToString())
+	for i, n := range nts {
+		if r.noteSeverity(n.Type) < r.minSev || r.isLazy(i) {
+			continue
+		}
+		r.Messages = append(r.Messages, r.render(n))
+	}
+}
+
+// updateMessagePrealloc renders only the notes added since the last update into
+// the reserved storage. All notes are rendered again if notes were removed.
+func (r *Result) updateMessagePrealloc(nts []l.LogInfo) {
+	if r.rendered == 0 || r.rendered > len(nts) {
+		r.rendered = 0
+		r.Messages = r.Messages[:0]
+	}
+	for i, n := range nts[r.rendered:] {
+		if r.noteSeverity(n.Type) < r.minSev || r.isLazy(r.rendered+i) {
+			continue
+		}
+		if r.msgFormatter != nil {
+			r.Messages = append(r.Messages, r.render(n))
+			continue
+		}
+		r.scratch = r.scratch[:0]
+		if n.Type != l.App {
+			r.scratch = append(r.scratch, n.Type...)
+			if n.Prefix != "" {
+				r.scratch = append(r.scratch, '[')
+				r.scratch = append(r.scratch, n.Prefix...)
+				r.scratch = append(r.scratch, ']')
+			}
+			r.scratch = append(r.scratch, l.DelimMsgType...)
+		}
+		r.scratch = append(r.scratch, n.Message...)
+		r.Messages = append(r.Messages, string(r.scratch))
+	}
+	r.rendered = len(nts)
+}
+
+// render returns the note as a string using the message formatter if set
+func (r *Result) render(n l.LogInfo) string {
+	if r.msgFormatter == nil {
+		return n.ToString()
 	}
+	return r.msgFormatter(Message{
+		Type:    n.Type,
+		Prefix:  n.Prefix,
+		Message: n.Message,
+	})
 }
@@ -0,0 +1,405 @@
+package result
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	l "github.com/stdutil/log"
+)
+
+func TestAddErrorWithAlt(t *testing.T) {
+	tests := []struct {
+		name  string
+		opts  []InitResultOption
+		rs    Result
+		alt   string
+		args  []any
+		want  []string
+		hooks int
+	}{
+		{"failed result", nil, func() Result {
+			r := InitResult()
+			r.AddError("upstream")
+			return r
+		}(), "alt", nil, []string{"ERR: upstream"}, 0},
+		{"alternative", nil, InitResult(WithStatus(OK)), "alt %d", []any{1}, []string{"ERR: alt 1"}, 1},
+		{"alternative as is", nil, InitResult(WithStatus(OK)), "100%", nil, []string{"ERR: 100%"}, 1},
+		{"truncated", []InitResultOption{WithMaxMessageLength(5)}, InitResult(WithStatus(OK)), "alternative", nil, []string{"ERR: alter…"}, 1},
+		{"no alternative", nil, InitResult(WithStatus(OK)), "", nil, []string{}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := 0
+			opts := append([]InitResultOption{WithHooks(Hooks{AfterAdd: func(*Result, Message) { n++ }})}, tt.opts...)
+			r := InitResult(opts...)
+			r.AddErrorWithAlt(tt.rs, tt.alt, tt.args...)
+			if !reflect.DeepEqual(r.Messages, tt.want) {
+				t.Fatalf("got %q, want %q", r.Messages, tt.want)
+			}
+			if n != tt.hooks {
+				t.Fatalf("hooks called %d times, want %d", n, tt.hooks)
+			}
+			if len(tt.want) > 0 && tt.hooks > 0 && r.StructuredMessages()[0].Seq == 0 {
+				t.Fatal("alternative message has no sequence number")
+			}
+		})
+	}
+}
+
+func TestAddFormatting(t *testing.T) {
+	tests := []struct {
+		name string
+		add  func(r *Result)
+		want string
+	}{
+		{"formatted", func(r *Result) { r.AddInfo("%d rows", 2) }, "INF: 2 rows"},
+		{"text", func(r *Result) { r.AddWarningText("%s %d") }, "WRN: %s %d"},
+		{"success text", func(r *Result) { r.AddSuccessText("done %") }, "SUC: done %"},
+		{"err", func(r *Result) { r.AddErr(errors.New("boom")) }, "ERR: boom"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := InitResult()
+			tt.add(&r)
+			if got := r.MessagesToString(); got != tt.want {
+				t.Fatalf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+type fakeSQLResult struct {
+	rows, id       int64
+	rowsErr, idErr error
+}
+
+func (f fakeSQLResult) RowsAffected() (int64, error) { return f.rows, f.rowsErr }
+func (f fakeSQLResult) LastInsertId() (int64, error) { return f.id, f.idErr }
+
+func TestFromSQLResult(t *testing.T) {
+	unsupported := errors.New("not supported")
+	tests := []struct {
+		name   string
+		opts   []InitResultOption
+		res    sql.Result
+		err    error
+		status Status
+		want   []string
+		id     *int64
+	}{
+		{"inserted", nil, fakeSQLResult{rows: 1, id: 42}, nil, OK, []string{"INF: 1 rows affected"}, ptr(int64(42))},
+		{"no rows", nil, fakeSQLResult{}, nil, OK, []string{"INF: No rows affected"}, ptr(int64(0))},
+		{"no rows as warning", []InitResultOption{WithZeroRowsAsWarning(true), WithZeroRowsStatus(INVALID)},
+			fakeSQLResult{}, nil, INVALID, []string{"WRN: No rows affected"}, ptr(int64(0))},
+		{"unsupported", nil, fakeSQLResult{rowsErr: unsupported, idErr: unsupported}, nil, OK, []string{}, nil},
+		{"nil result", nil, nil, nil, OK, []string{}, nil},
+		{"error", nil, fakeSQLResult{rows: 1, id: 1}, errors.New("duplicate key"), EXCEPTION, []string{"ERR: duplicate key"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := InitResult(tt.opts...)
+			r.FromSQLResult(tt.res, tt.err)
+			if r.Status != string(tt.status) {
+				t.Fatalf("got status %s", r.Status)
+			}
+			if !reflect.DeepEqual(r.Messages, tt.want) {
+				t.Fatalf("got %q, want %q", r.Messages, tt.want)
+			}
+			if !reflect.DeepEqual(r.LastInsertID, tt.id) {
+				t.Fatalf("got id %v, want %v", r.LastInsertID, tt.id)
+			}
+			if tt.err != nil && !errors.Is(r.ToError(), tt.err) {
+				t.Fatalf("error not retained: %v", r.ToError())
+			}
+		})
+	}
+}
+
+func ptr[T any](v T) *T { return &v }
+
+func TestHasUserContent(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []InitResultOption
+		add  func(r *Result)
+		want bool
+	}{
+		{"empty", nil, func(r *Result) {}, false},
+		{"application message only", nil, func(r *Result) { r.AddRawMsg("raw") }, false},
+		{"info", nil, func(r *Result) { r.AddInfo("done") }, true},
+		{"error", nil, func(r *Result) { r.AddError("boom") }, true},
+		{"info below minimum", []InitResultOption{WithMinSeverity(SeverityWarning)}, func(r *Result) { r.AddInfo("done") }, false},
+		{"warning at minimum", []InitResultOption{WithMinSeverity(SeverityWarning)}, func(r *Result) { r.AddWarning("careful") }, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := InitResult(append([]InitResultOption{WithStatus(OK)}, tt.opts...)...)
+			tt.add(&r)
+			if got := r.HasUserContent(); got != tt.want {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHooks(t *testing.T) {
+	var calls []string
+	record := func(name string) func(r *Result, m Message) {
+		return func(r *Result, m Message) {
+			calls = append(calls, fmt.Sprintf("%s %s %s %d", name, m.Type, m.Message, len(r.Messages)))
+		}
+	}
+	defer func(h Hooks) { GlobalHooks = h }(GlobalHooks)
+	GlobalHooks = Hooks{BeforeAdd: record("global-before"), AfterAdd: record("global-after")}
+	tests := []struct {
+		name string
+		opts []InitResultOption
+		want []string
+	}{
+		{"global", nil, []string{"global-before ERR boom 0", "global-after ERR boom 1"}},
+		{"override both", []InitResultOption{WithHooks(Hooks{BeforeAdd: record("before"), AfterAdd: record("after")})},
+			[]string{"before ERR boom 0", "after ERR boom 1"}},
+		{"override after", []InitResultOption{WithHooks(Hooks{AfterAdd: record("after")})},
+			[]string{"global-before ERR boom 0", "after ERR boom 1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls = nil
+			r := InitResult(tt.opts...)
+			r.AddError("  boom ")
+			if !reflect.DeepEqual(calls, tt.want) {
+				t.Fatalf("got %q, want %q", calls, tt.want)
+			}
+		})
+	}
+}
+
+func TestNoteSeq(t *testing.T) {
+	r := InitResult(WithStatus(OK))
+	r.AddInfo("one")
+	r.AddWarning("two")
+	other := InitResult(WithStatus(OK))
+	other.AddError("three")
+	r.Stuff(other)
+	r.AddInfo("four")
+	var seqs []uint64
+	for _, m := range r.StructuredMessages() {
+		seqs = append(seqs, m.Seq)
+	}
+	if want := []uint64{1, 2, 3, 4}; !reflect.DeepEqual(seqs, want) {
+		t.Fatalf("got %v, want %v", seqs, want)
+	}
+}
+
+func TestDefaultPrefix(t *testing.T) {
+	defer func(p string) { DefaultPrefix = p }(DefaultPrefix)
+	DefaultPrefix = "app"
+	tests := []struct {
+		name string
+		opts []InitResultOption
+		want string
+	}{
+		{"default", nil, "INF[app]: a"},
+		{"option", []InitResultOption{WithPrefix("svc")}, "INF[svc]: a"},
+		{"empty option", []InitResultOption{WithPrefix("")}, "INF: a"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := InitResult(append([]InitResultOption{WithStatus(OK)}, tt.opts...)...)
+			r.AddInfo("a")
+			if !reflect.DeepEqual(r.Messages, []string{tt.want}) {
+				t.Fatalf("got %q, want %q", r.Messages, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithDedupOnMerge(t *testing.T) {
+	shared := InitResult(WithStatus(OK))
+	shared.AddInfo("loaded config")
+	shared.AddWarning("slow")
+	tests := []struct {
+		name  string
+		dedup bool
+		want  []string
+	}{
+		{"off", false, []string{"INF: loaded config", "WRN: slow", "INF: loaded config", "WRN: slow", "INF: own"}},
+		{"on", true, []string{"INF: loaded config", "WRN: slow", "INF: own"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := InitResult(WithStatus(OK), WithDedupOnMerge(tt.dedup))
+			r.Stuff(shared)
+			r.AppendInfo(shared, "own")
+			if !reflect.DeepEqual(r.Messages, tt.want) {
+				t.Fatalf("got %q, want %q", r.Messages, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithDedupOnMergeKeepsOwnDuplicates(t *testing.T) {
+	r := InitResult(WithStatus(OK), WithDedupOnMerge(true))
+	r.AddInfo("same")
+	r.AddInfo("same")
+	other := InitResult(WithStatus(OK))
+	other.AddInfo("same")
+	other.AddInfo("new")
+	other.AddInfo("new")
+	r.Stuff(other)
+	if want := []string{"INF: same", "INF: same", "INF: new"}; !reflect.DeepEqual(r.Messages, want) {
+		t.Fatalf("got %q, want %q", r.Messages, want)
+	}
+}
+
+func TestAbsorbByType(t *testing.T) {
+	src := InitResult()
+	src.AddInfo("i")
+	src.AddWarning("w")
+	src.AddError("e")
+	src.AddSuccess("s")
+	tests := []struct {
+		name  string
+		types []l.LogType
+		want  []string
+	}{
+		{"none", nil, []string{}},
+		{"errors", []l.LogType{l.Error}, []string{"ERR: e"}},
+		{"order kept", []l.LogType{l.Success, l.Warn}, []string{"WRN: w", "SUC: s"}},
+		{"absent type", []l.LogType{l.Fatal}, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := InitResult(WithStatus(OK))
+			r.AbsorbByType(src, tt.types...)
+			if !reflect.DeepEqual(r.Messages, tt.want) {
+				t.Fatalf("got %q, want %q", r.Messages, tt.want)
+			}
+			if r.Status != string(OK) {
+				t.Fatalf("status changed to %s", r.Status)
+			}
+		})
+	}
+}
+
+func TestAddErrorWith(t *testing.T) {
+	tests := []struct {
+		name  string
+		opts  []InitResultOption
+		attrs map[string]any
+		want  []string
+		got   map[string]any
+	}{
+		{"attributes", nil, map[string]any{"row": 3, "tags": []string{"a"}}, []string{"ERR: row 3 invalid"},
+			map[string]any{"row": 3, "tags": []string{"a"}}},
+		{"nil attributes", nil, nil, []string{"ERR: row 3 invalid"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := InitResult(tt.opts...)
+			r.AddErrorWith(tt.attrs, "row %d invalid", 3)
+			if !reflect.DeepEqual(r.Messages, tt.want) {
+				t.Fatalf("got %q, want %q", r.Messages, tt.want)
+			}
+			if got := r.StructuredMessages()[0].Attrs; !reflect.DeepEqual(got, tt.got) {
+				t.Fatalf("got attrs %v, want %v", got, tt.got)
+			}
+		})
+	}
+}
+
+func TestAddErrorWithCopiesAttrs(t *testing.T) {
+	attrs := map[string]any{"ids": []any{1, 2}}
+	r := InitResult()
+	r.AddErrorWith(attrs, "bad")
+	attrs["ids"].([]any)[0] = 9
+	attrs["new"] = true
+	m := r.StructuredMessages()[0]
+	if want := map[string]any{"ids": []any{1, 2}}; !reflect.DeepEqual(m.Attrs, want) {
+		t.Fatalf("got %v, want %v", m.Attrs, want)
+	}
+	m.Attrs["ids"].([]any)[1] = 9
+	if got := r.StructuredMessages()[0].Attrs["ids"].([]any)[1]; got != 2 {
+		t.Fatalf("attrs changed through a structured message: %v", got)
+	}
+}
+
+func TestAddErrorWithKeepsReferences(t *testing.T) {
+	sentinel := errors.New("sentinel")
+	n := 1
+	self := map[string]any{"name": "self"}
+	self["self"] = self
+	r := InitResult()
+	r.AddErrorWith(map[string]any{"errs": []error{sentinel}, "n": &n, "self": self}, "bad")
+	attrs := r.StructuredMessages()[0].Attrs
+	if errs := attrs["errs"].([]error); !errors.Is(errs[0], sentinel) {
+		t.Fatalf("got %v", errs[0])
+	}
+	if attrs["n"].(*int) != &n {
+		t.Fatal("pointer was copied")
+	}
+	c := attrs["self"].(map[string]any)
+	if c["name"] != "self" || reflect.ValueOf(c["self"]).Pointer() != reflect.ValueOf(c).Pointer() {
+		t.Fatalf("got %v", c)
+	}
+	if reflect.ValueOf(c).Pointer() == reflect.ValueOf(self).Pointer() {
+		t.Fatal("map was not copied")
+	}
+}
+
+func TestAddEscalatingWarning(t *testing.T) {
+	tests := []struct {
+		name      string
+		threshold int
+		keys      []string
+		want      []string
+		status    Status
+	}{
+		{"no threshold", 0, []string{"k", "k", "k"}, []string{"WRN: slow k", "WRN: slow k", "WRN: slow k"}, OK},
+		{"below", 2, []string{"k", "k"}, []string{"WRN: slow k", "WRN: slow k"}, OK},
+		{"exceeded", 2, []string{"k", "k", "k", "k"}, []string{"WRN: slow k", "WRN: slow k", "ERR: slow k", "ERR: slow k"}, EXCEPTION},
+		{"counted by key", 1, []string{"a", "b", "a"}, []string{"WRN: slow a", "WRN: slow b", "ERR: slow a"}, EXCEPTION},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := InitResult(WithStatus(OK), WithEscalationThreshold(tt.threshold))
+			for _, k := range tt.keys {
+				r.AddEscalatingWarning(k, "slow %s", k)
+			}
+			if !reflect.DeepEqual(r.Messages, tt.want) {
+				t.Fatalf("got %q, want %q", r.Messages, tt.want)
+			}
+			if r.Status != string(tt.status) {
+				t.Fatalf("got status %s", r.Status)
+			}
+		})
+	}
+}
+
+func TestRowsAffectedInfo(t *testing.T) {
+	tests := []struct {
+		name   string
+		opts   []InitResultOption
+		rows   int64
+		want   string
+		status Status
+	}{
+		{"rows", []InitResultOption{WithZeroRowsAsWarning(true)}, 3, "INF: 3 rows affected", OK},
+		{"zero as info", nil, 0, "INF: No rows affected", OK},
+		{"zero as warning", []InitResultOption{WithZeroRowsAsWarning(true)}, 0, "WRN: No rows affected", OK},
+		{"zero with status", []InitResultOption{WithZeroRowsAsWarning(true), WithZeroRowsStatus(NO)}, 0, "WRN: No rows affected", NO},
+		{"status without warning", []InitResultOption{WithZeroRowsStatus(NO)}, 0, "INF: No rows affected", OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := InitResult(append([]InitResultOption{WithStatus(OK)}, tt.opts...)...)
+			r.RowsAffectedInfo(tt.rows)
+			if !reflect.DeepEqual(r.Messages, []string{tt.want}) || r.Status != string(tt.status) {
+				t.Fatalf("got %q with status %s", r.Messages, r.Status)
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package result
+
+import "net/http"
+
+// Resulter is the set of read methods shared by Result and ResultAny.
+// Generic middleware can accept a Resulter to inspect and write any kind of result.
+type Resulter interface {
+	OK() bool
+	Error() bool
+	Valid() bool
+	Invalid() bool
+	Yes() bool
+	No() bool
+	MessagesToString() string
+	HTTPStatusCode() int
+	WriteHTTP(w http.ResponseWriter)
+}
+
+var (
+	_ Resulter = (*Result)(nil)
+	_ Resulter = (*ResultAny[any])(nil)
+	_ Resulter = (*PagedResult[any])(nil)
+)
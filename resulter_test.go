@@ -0,0 +1,43 @@
+package result
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestResulter(t *testing.T) {
+	res := InitResult(WithStatus(INVALID))
+	res.AddError("bad")
+	anyRes := ResultAny[int]{Result: InitResult(WithStatus(OK)), Data: 1}
+	paged := NewPagedResult([]int{1}, 1, 10, 1, WithStatus(NO))
+	tests := []struct {
+		name string
+		r    Resulter
+		ok   bool
+		no   bool
+		code int
+		key  string
+	}{
+		{"result", &res, false, false, http.StatusBadRequest, "messages"},
+		{"result any", &anyRes, true, false, http.StatusOK, "data"},
+		{"paged result", &paged, false, true, http.StatusOK, "items"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.r.OK() != tt.ok || tt.r.No() != tt.no || tt.r.HTTPStatusCode() != tt.code {
+				t.Fatalf("got ok %v, no %v, code %d", tt.r.OK(), tt.r.No(), tt.r.HTTPStatusCode())
+			}
+			rec := httptest.NewRecorder()
+			tt.r.WriteHTTP(rec)
+			var m map[string]json.RawMessage
+			if err := json.Unmarshal(rec.Body.Bytes(), &m); err != nil {
+				t.Fatal(err)
+			}
+			if rec.Code != tt.code || m[tt.key] == nil {
+				t.Fatalf("got code %d, body %s", rec.Code, rec.Body)
+			}
+		})
+	}
+}
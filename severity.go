@@ -0,0 +1,126 @@
+package result
+
+import (
+	"net/http"
+	"sync"
+
+	l "github.com/stdutil/log"
+)
+
+// Severity levels
+const (
+	SeverityNone    Severity = iota // Application messages and unknown statuses
+	SeverityInfo                    // Information and success messages, OK, VALID and YES statuses
+	SeverityWarning                 // Warning messages
+	SeverityError                   // Error and fatal messages, EXCEPTION, INVALID and NO statuses
+)
+
+type statusInfo struct {
+	severity Severity
+	httpCode int
+	title    string
+}
+
+var (
+	statusMu  sync.RWMutex
+	statusReg = map[Status]statusInfo{
+		TIMEOUT: {severity: SeverityError, httpCode: http.StatusGatewayTimeout, title: "Request timed out"},
+	}
+)
+
+// RegisterStatus registers a custom status with its severity and HTTP status code.
+// The built-in statuses can not be changed. A httpCode of 0 keeps the default mapping.
+func RegisterStatus(s Status, sev Severity, httpCode int) {
+	if isBuiltIn(s) {
+		return
+	}
+	statusMu.Lock()
+	defer statusMu.Unlock()
+	statusReg[s] = statusInfo{severity: sev, httpCode: httpCode, title: statusReg[s].title}
+}
+
+// RegisterStatusTitle sets the title returned by Title for a registered status.
+// Without a title, Title returns the status itself. Unregistered and built-in
+// statuses are ignored.
+func RegisterStatusTitle(s Status, title string) {
+	statusMu.Lock()
+	defer statusMu.Unlock()
+	si, ok := statusReg[s]
+	if !ok {
+		return
+	}
+	si.title = title
+	statusReg[s] = si
+}
+
+// registeredStatus returns the registration of a custom status
+func registeredStatus(s Status) (statusInfo, bool) {
+	statusMu.RLock()
+	defer statusMu.RUnlock()
+	si, ok := statusReg[s]
+	return si, ok
+}
+
+func isBuiltIn(s Status) bool {
+	switch s {
+	case OK, EXCEPTION, VALID, INVALID, YES, NO:
+		return true
+	}
+	return false
+}
+
+// Severity returns the severity of the status.
+// Registered statuses have their registered severity.
+func (s Status) Severity() Severity {
+	switch s {
+	case OK, VALID, YES:
+		return SeverityInfo
+	case EXCEPTION, INVALID, NO:
+		return SeverityError
+	}
+	if si, ok := registeredStatus(s); ok {
+		return si.severity
+	}
+	return SeverityNone
+}
+
+// CoerceStatus returns the built-in status nearest to the status of the Result.
+// Built-in statuses are returned as is. Other statuses are mapped by severity:
+// error to EXCEPTION, warning to INVALID, information to OK, and unknown
+// statuses to EXCEPTION. When WithCoerceStatus is on, the status of the
+// Result is set to the coerced status by Return, so the allowed status
+// transitions apply.
+func (r *Result) CoerceStatus() Status {
+	s := Status(r.Status)
+	if !isBuiltIn(s) {
+		switch s.Severity() {
+		case SeverityInfo:
+			s = OK
+		case SeverityWarning:
+			s = INVALID
+		default:
+			s = EXCEPTION
+		}
+	}
+	if r.coerce && r.Status != string(s) {
+		r.Return(s)
+	}
+	return s
+}
+
+// noteSeverity returns the severity of a note type.
+// Warnings are errors when the Result treats warnings as errors.
+func (r *Result) noteSeverity(t l.LogType) Severity {
+	switch t {
+	case l.Info, l.Success:
+		return SeverityInfo
+	case l.Warn:
+		if r.warnAsErr {
+			return SeverityError
+		}
+		return SeverityWarning
+	case l.Error, l.Fatal:
+		return SeverityError
+	}
+	return SeverityNone
+}
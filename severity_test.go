@@ -0,0 +1,115 @@
+package result
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestAddTimeout(t *testing.T) {
+	r := InitResult(WithStatus(OK))
+	r.AddTimeout("fetch", 2*time.Second)
+	if want := []string{"ERR: fetch timed out after 2s"}; len(r.Messages) != 1 || r.Messages[0] != want[0] {
+		t.Fatalf("got %q, want %q", r.Messages, want)
+	}
+	if r.Status != string(TIMEOUT) || !r.Retryable {
+		t.Fatalf("got status %s, retryable %v", r.Status, r.Retryable)
+	}
+	if got := r.HTTPStatusCode(); got != http.StatusGatewayTimeout {
+		t.Fatalf("got code %d", got)
+	}
+	if got := r.Title(); got != "Request timed out" {
+		t.Fatalf("got title %q", got)
+	}
+}
+
+func TestStatusRegistry(t *testing.T) {
+	RegisterStatus("RATE_LIMITED", SeverityWarning, http.StatusTooManyRequests)
+	RegisterStatusTitle("RATE_LIMITED", "Too many requests")
+	RegisterStatus("QUEUED", SeverityInfo, 0)
+	RegisterStatusTitle("UNREGISTERED", "ignored")
+	RegisterStatus(OK, SeverityError, http.StatusTeapot)
+	tests := []struct {
+		status  Status
+		sev     Severity
+		code    int
+		title   string
+		coerced Status
+	}{
+		{OK, SeverityInfo, http.StatusOK, "Success", OK},
+		{NO, SeverityError, http.StatusOK, "No", NO},
+		{INVALID, SeverityError, http.StatusBadRequest, "Validation failed", INVALID},
+		{TIMEOUT, SeverityError, http.StatusGatewayTimeout, "Request timed out", EXCEPTION},
+		{"RATE_LIMITED", SeverityWarning, http.StatusTooManyRequests, "Too many requests", INVALID},
+		{"QUEUED", SeverityInfo, http.StatusOK, "QUEUED", OK},
+		{"UNREGISTERED", SeverityNone, http.StatusOK, "UNREGISTERED", EXCEPTION},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.status), func(t *testing.T) {
+			if got := tt.status.Severity(); got != tt.sev {
+				t.Fatalf("got severity %d, want %d", got, tt.sev)
+			}
+			r := InitResult(WithStatus(tt.status), WithCoerceStatus(true))
+			if got := r.HTTPStatusCode(); got != tt.code {
+				t.Fatalf("got code %d, want %d", got, tt.code)
+			}
+			if got := r.Title(); got != tt.title {
+				t.Fatalf("got title %q, want %q", got, tt.title)
+			}
+			if got := r.CoerceStatus(); got != tt.coerced || r.Status != string(tt.coerced) {
+				t.Fatalf("got coerced %s, status %s, want %s", got, r.Status, tt.coerced)
+			}
+		})
+	}
+}
+
+func TestCoerceStatusWithoutOption(t *testing.T) {
+	RegisterStatus("QUEUED", SeverityInfo, 0)
+	tests := []struct {
+		status Status
+		want   Status
+	}{
+		{OK, OK},
+		{NO, NO},
+		{"QUEUED", OK},
+		{TIMEOUT, EXCEPTION},
+		{"UNKNOWN", EXCEPTION},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.status), func(t *testing.T) {
+			r := InitResult(WithStatus(tt.status))
+			if got := r.CoerceStatus(); got != tt.want {
+				t.Fatalf("got %s, want %s", got, tt.want)
+			}
+			if r.Status != string(tt.status) {
+				t.Fatalf("status changed to %s", r.Status)
+			}
+		})
+	}
+}
+
+func TestCoerceStatusHonoursTransitions(t *testing.T) {
+	RegisterStatus("QUEUED", SeverityInfo, 0)
+	tests := []struct {
+		name   string
+		strict bool
+		status Status
+		want   []string
+	}{
+		{"allowed with a warning", false, OK, []string{"WRN: Status transition from QUEUED to OK is not allowed"}},
+		{"blocked", true, "QUEUED", []string{"ERR: Status transition from QUEUED to OK is not allowed"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := InitResult(WithStatus("QUEUED"), WithCoerceStatus(true), WithStrictTransitions(tt.strict))
+			r.SetAllowedTransitions(map[Status][]Status{"QUEUED": {}})
+			if got := r.CoerceStatus(); got != OK {
+				t.Fatalf("got %s", got)
+			}
+			if r.Status != string(tt.status) || !reflect.DeepEqual(r.Messages, tt.want) {
+				t.Fatalf("got status %s, messages %q", r.Status, r.Messages)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package result
+
+import l "github.com/stdutil/log"
+
+// SplitByOperation splits the Result into a Result per operation that added the
+// notes, such as after stuffing the results of several operations. Notes without
+// an operation belong to the Operation of this Result. Each Result has only the
+// notes of its operation, their grouped errors, the options of this Result and a
+// status derived from the notes: EXCEPTION if there are errors, or OK otherwise.
+// The "all" entry is a copy of the whole Result, and is the only one that keeps
+// the other fields such as the pagination, Meta and Cause. Notes of an operation
+// named "all" are only in the "all" entry.
+func (r *Result) SplitByOperation() map[string]Result {
+	split := map[string]Result{
+		"all": r.clone(map[*Result]bool{}),
+	}
+	for i, n := range r.ln.Notes() {
+		meta := r.metaOf(i)
+		op := meta.op
+		if op == "" {
+			op = r.Operation
+		}
+		if op == "all" {
+			continue // already in the all entry
+		}
+		sub, ok := split[op]
+		if !ok {
+			sub = r.settings()
+			sub.Operation = op
+			sub.eventVerb = op
+			sub.Status = string(OK)
+		}
+		sub.ln.Append(n)
+		sub.seq++
+		meta.seq = sub.seq
+		sub.nmeta = append(sub.nmeta, meta)
+		if meta.group != "" {
+			if sub.groups == nil {
+				sub.groups = make(map[string][]string)
+			}
+			sub.groups[meta.group] = append(sub.groups[meta.group], meta.groupMsg)
+		}
+		if sub.noteSeverity(n.Type) >= SeverityError {
+			sub.Status = string(EXCEPTION)
+		}
+		split[op] = sub
+	}
+	for op, sub := range split {
+		if op != "all" {
+			sub.updateMessage()
+			split[op] = sub
+		}
+	}
+	return split
+}
+
+// settings returns an empty Result with the options of this Result.
+// Reserved storage is not copied.
+func (r *Result) settings() Result {
+	c := Result{
+		Messages:          make([]string, 0),
+		Prefix:            r.Prefix,
+		ln:                l.Log{Prefix: r.ln.Prefix},
+		osIsWin:           r.osIsWin,
+		useOperationInMsg: r.useOperationInMsg,
+		strictTmpl:        r.strictTmpl,
+		title:             r.title,
+		titleDetail:       r.titleDetail,
+		escThreshold:      r.escThreshold,
+		maxMsgLen:         r.maxMsgLen,
+		failFast:          r.failFast,
+		msgFormatter:      r.msgFormatter,
+		timeLoc:           r.timeLoc,
+		strictTrans:       r.strictTrans,
+		warnAsErr:         r.warnAsErr,
+		zeroRowsWarn:      r.zeroRowsWarn,
+		zeroRowsStatus:    r.zeroRowsStatus,
+		scalarMsg:         r.scalarMsg,
+		minSev:            r.minSev,
+		dedupMerge:        r.dedupMerge,
+		groupFocus:        r.groupFocus,
+		successFlag:       r.successFlag,
+		inclErrType:       r.inclErrType,
+		opMsgFmt:          r.opMsgFmt,
+		hooks:             r.hooks,
+		coerce:            r.coerce,
+		clock:             r.clock,
+		fcFormatter:       r.fcFormatter,
+	}
+	c.SetAllowedTransitions(r.transitions)
+	return c
+}
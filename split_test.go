@@ -0,0 +1,67 @@
+package result
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitByOperation(t *testing.T) {
+	load := InitResult(WithStatus(OK))
+	load.Operation = "load"
+	load.AddInfo("loaded")
+	save := InitResult(WithStatus(EXCEPTION))
+	save.Operation = "save"
+	save.AddGroupedError("billing", "card declined")
+	check := InitResult(WithStatus(OK))
+	check.Operation = "check"
+	check.AddWarning("slow")
+
+	page := 2
+	all := InitResult(WithStatus(EXCEPTION))
+	all.Operation = "batch"
+	all.Page = &page
+	all.Meta = map[string]any{"k": 1}
+	all.SetCause(save)
+	all.NotePayload("body", []byte("x"))
+	all.AddInfoOnce("k", "once")
+	all.Stuff(load)
+	all.Stuff(save)
+	all.Stuff(check)
+
+	split := all.SplitByOperation()
+	tests := []struct {
+		op     string
+		status Status
+		msgs   []string
+		groups map[string][]string
+	}{
+		{"load", OK, []string{"INF: loaded"}, map[string][]string{}},
+		{"save", EXCEPTION, []string{"ERR: card declined"}, map[string][]string{"billing": {"card declined"}}},
+		{"check", OK, []string{"WRN: slow"}, map[string][]string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.op, func(t *testing.T) {
+			sub, ok := split[tt.op]
+			if !ok {
+				t.Fatal("missing")
+			}
+			if sub.Status != string(tt.status) || !reflect.DeepEqual(sub.Messages, tt.msgs) {
+				t.Fatalf("got %s %q", sub.Status, sub.Messages)
+			}
+			if got := sub.GroupedErrors(); !reflect.DeepEqual(got, tt.groups) {
+				t.Fatalf("got groups %v", got)
+			}
+			if sub.Page != nil || sub.Meta != nil || sub.Cause != nil || sub.PayloadHashes != nil || len(sub.Phases()) != 0 {
+				t.Fatalf("got data of the whole result %+v", sub)
+			}
+			before := len(sub.Messages)
+			sub.AddInfoOnce("k", "once")
+			if len(sub.Messages) != before+1 {
+				t.Fatal("once keys of the whole result kept")
+			}
+		})
+	}
+	if a := split["all"]; *a.Page != 2 || len(a.Messages) != 5 || a.Cause == nil {
+		t.Fatalf("got all %+v", a)
+	}
+}
@@ -0,0 +1,110 @@
+package result
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+)
+
+type (
+	// StreamWriter writes results as newline-delimited JSON (NDJSON) lines
+	StreamWriter struct {
+		w       io.Writer
+		enc     *json.Encoder
+		mu      sync.Mutex
+		summary StreamSummary
+		closed  bool
+	}
+	// StreamSummary is the final line written by StreamWriter.Close
+	StreamSummary struct {
+		Total    int            `json:"total"`    // Number of results written
+		Messages int            `json:"messages"` // Number of messages across all results
+		Statuses map[string]int `json:"statuses"` // Number of results per status
+	}
+)
+
+// NewStreamWriter creates a StreamWriter that writes to w
+func NewStreamWriter(w io.Writer) *StreamWriter {
+	return &StreamWriter{
+		w:   w,
+		enc: json.NewEncoder(w),
+		summary: StreamSummary{
+			Statuses: make(map[string]int),
+		},
+	}
+}
+
+// Write encodes the result as a single NDJSON line and flushes the underlying writer
+func (sw *StreamWriter) Write(r Result) error {
+	r.resolveLazy()
+	b, err := r.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	return sw.write(b, r)
+}
+
+// WriteAny encodes a ResultAny as a single NDJSON line and flushes the underlying writer.
+// This is a function because methods can not have type parameters.
+func WriteAny[T any](sw *StreamWriter, r ResultAny[T]) error {
+	r.resolveLazy()
+	b, err := r.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	return sw.write(b, r.Result)
+}
+
+// Close writes a final summary line with the aggregate counts.
+// It does not close the underlying writer.
+func (sw *StreamWriter) Close() error {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+	if sw.closed {
+		return nil
+	}
+	sw.closed = true
+	if err := sw.enc.Encode(struct {
+		Summary StreamSummary `json:"summary"`
+	}{sw.summary}); err != nil {
+		return err
+	}
+	return sw.flush()
+}
+
+// Summary returns the aggregate counts of the results written so far
+func (sw *StreamWriter) Summary() StreamSummary {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+	s := sw.summary
+	s.Statuses = make(map[string]int, len(sw.summary.Statuses))
+	for k, v := range sw.summary.Statuses {
+		s.Statuses[k] = v
+	}
+	return s
+}
+
+func (sw *StreamWriter) write(b []byte, r Result) error {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+	if sw.closed {
+		return io.ErrClosedPipe
+	}
+	if _, err := sw.w.Write(append(b, '\n')); err != nil {
+		return err
+	}
+	sw.summary.Total++
+	sw.summary.Messages += len(r.Messages)
+	sw.summary.Statuses[r.Status]++
+	return sw.flush()
+}
+
+func (sw *StreamWriter) flush() error {
+	switch f := sw.w.(type) {
+	case interface{ Flush() error }:
+		return f.Flush()
+	case interface{ Flush() }:
+		f.Flush()
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package result
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+)
+
+type flushRecorder struct {
+	bytes.Buffer
+	flushes int
+}
+
+func (f *flushRecorder) Flush() { f.flushes++ }
+
+type failWriter struct{}
+
+func (failWriter) Write([]byte) (int, error) { return 0, errors.New("disk full") }
+
+func TestStreamWriter(t *testing.T) {
+	ok := InitResult(WithStatus(OK))
+	ok.AddInfo("one")
+	bad := InitResult()
+	bad.AddError("boom")
+	bad.AddWarning("careful")
+	data := ResultAny[[]int]{Result: InitResult(WithStatus(OK)), Data: []int{1, 2}}
+	data.AddInfoLazy(func() string { return "two" })
+
+	var w flushRecorder
+	sw := NewStreamWriter(&w)
+	for _, r := range []Result{ok, bad} {
+		if err := sw.Write(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := WriteAny(sw, data); err != nil {
+		t.Fatal(err)
+	}
+	if err := sw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := sw.Close(); err != nil {
+		t.Fatalf("second close: %v", err)
+	}
+	if err := sw.Write(ok); !errors.Is(err, io.ErrClosedPipe) {
+		t.Fatalf("write after close: %v", err)
+	}
+	if w.flushes != 4 {
+		t.Fatalf("got %d flushes", w.flushes)
+	}
+
+	var lines []map[string]json.RawMessage
+	sc := bufio.NewScanner(&w.Buffer)
+	for sc.Scan() {
+		var m map[string]json.RawMessage
+		if err := json.Unmarshal(sc.Bytes(), &m); err != nil {
+			t.Fatalf("line %d: %v: %s", len(lines), err, sc.Bytes())
+		}
+		lines = append(lines, m)
+	}
+	if len(lines) != 4 {
+		t.Fatalf("got %d lines", len(lines))
+	}
+	tests := []struct {
+		line int
+		key  string
+		want string
+	}{
+		{0, "status", `"OK"`},
+		{0, "messages", `["INF: one"]`},
+		{1, "status", `"EXCEPTION"`},
+		{1, "messages", `["ERR: boom","WRN: careful"]`},
+		{2, "data", `[1,2]`},
+		{2, "messages", `["INF: two"]`},
+		{3, "summary", `{"total":3,"messages":4,"statuses":{"EXCEPTION":1,"OK":2}}`},
+	}
+	for _, tt := range tests {
+		if got := string(lines[tt.line][tt.key]); got != tt.want {
+			t.Errorf("line %d %s: got %s, want %s", tt.line, tt.key, got, tt.want)
+		}
+	}
+	want := StreamSummary{Total: 3, Messages: 4, Statuses: map[string]int{"OK": 2, "EXCEPTION": 1}}
+	if got := sw.Summary(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got summary %+v", got)
+	}
+}
+
+func TestStreamWriterError(t *testing.T) {
+	sw := NewStreamWriter(failWriter{})
+	if err := sw.Write(InitResult(WithStatus(OK))); err == nil {
+		t.Fatal("got no error")
+	}
+	if got := sw.Summary(); got.Total != 0 {
+		t.Fatalf("failed write counted: %+v", got)
+	}
+}
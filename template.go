@@ -0,0 +1,102 @@
+package result
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+type (
+	// msgTemplate is a compiled message template
+	msgTemplate struct {
+		parts []tmplPart
+	}
+	// tmplPart is either a literal text or a named placeholder
+	tmplPart struct {
+		text  string
+		isKey bool
+	}
+)
+
+// maxCachedTemplates is the number of compiled templates kept in the cache.
+// Templates built from dynamic strings would otherwise grow the cache without bound.
+const maxCachedTemplates = 512
+
+var (
+	tmplCache sync.Map     // compiled templates keyed by the template string
+	tmplCount atomic.Int64 // number of templates in the cache
+)
+
+// AddTemplatedError adds an error message rendered from a template with named
+// placeholders resolved from data, e.g. "User {user} not found", and returns itself.
+//
+// Missing keys leave the placeholder as is, unless the result was initialized
+// with WithStrictTemplates(true), in which case an error about the missing key is
+// added instead of the message.
+func (r *Result) AddTemplatedError(tmpl string, data map[string]any) Result {
+	msg, err := compileTemplate(tmpl).render(data, r.strictTmpl)
+	if err != nil {
+		return r.AddError("%s", err)
+	}
+	return r.AddError("%s", msg)
+}
+
+// compileTemplate parses the template or gets it from the cache.
+// Once the cache is full, new templates are parsed on every call.
+func compileTemplate(tmpl string) *msgTemplate {
+	if t, ok := tmplCache.Load(tmpl); ok {
+		return t.(*msgTemplate)
+	}
+	t := &msgTemplate{}
+	s := tmpl
+	for {
+		lpos := strings.Index(s, "{")
+		if lpos == -1 {
+			break
+		}
+		rpos := strings.Index(s[lpos:], "}")
+		if rpos == -1 {
+			break
+		}
+		rpos += lpos
+		if lpos > 0 {
+			t.parts = append(t.parts, tmplPart{text: s[:lpos]})
+		}
+		t.parts = append(t.parts, tmplPart{text: s[lpos+1 : rpos], isKey: true})
+		s = s[rpos+1:]
+	}
+	if s != "" {
+		t.parts = append(t.parts, tmplPart{text: s})
+	}
+	if tmplCount.Add(1) > maxCachedTemplates {
+		tmplCount.Add(-1)
+		return t
+	}
+	tc, loaded := tmplCache.LoadOrStore(tmpl, t)
+	if loaded {
+		tmplCount.Add(-1)
+	}
+	return tc.(*msgTemplate)
+}
+
+// render resolves the placeholders from data
+func (t *msgTemplate) render(data map[string]any, strict bool) (string, error) {
+	sb := strings.Builder{}
+	for _, p := range t.parts {
+		if !p.isKey {
+			sb.WriteString(p.text)
+			continue
+		}
+		v, ok := data[p.text]
+		if !ok {
+			if strict {
+				return "", fmt.Errorf("missing template key %q", p.text)
+			}
+			sb.WriteString("{" + p.text + "}")
+			continue
+		}
+		sb.WriteString(fmt.Sprint(v))
+	}
+	return sb.String(), nil
+}
@@ -0,0 +1,50 @@
+package result
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestAddTemplatedError(t *testing.T) {
+	tests := []struct {
+		name   string
+		tmpl   string
+		data   map[string]any
+		strict bool
+		want   string
+	}{
+		{"substitution", "User {user} not found in {org}", map[string]any{"user": "ann", "org": 7}, false, "ERR: User ann not found in 7"},
+		{"no placeholders", "plain", nil, false, "ERR: plain"},
+		{"missing key", "User {user} not found", nil, false, "ERR: User {user} not found"},
+		{"missing key strict", "User {user} not found", nil, true, `ERR: missing template key "user"`},
+		{"unclosed brace", "User {user", map[string]any{"user": "ann"}, false, "ERR: User {user"},
+		{"adjacent placeholders", "{a}{b}", map[string]any{"a": 1, "b": 2}, true, "ERR: 12"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := InitResult(WithStrictTemplates(tt.strict))
+			r.AddTemplatedError(tt.tmpl, tt.data)
+			if len(r.Messages) != 1 || r.Messages[0] != tt.want {
+				t.Fatalf("got %q, want %q", r.Messages, tt.want)
+			}
+		})
+	}
+}
+
+func TestTemplateCacheBound(t *testing.T) {
+	for i := range maxCachedTemplates + 100 {
+		tmpl := fmt.Sprintf("bound-%d {k}", i)
+		got, _ := compileTemplate(tmpl).render(map[string]any{"k": i}, true)
+		if want := fmt.Sprintf("bound-%d %d", i, i); got != want {
+			t.Fatalf("got %q, want %q", got, want)
+		}
+	}
+	n := 0
+	tmplCache.Range(func(any, any) bool {
+		n++
+		return true
+	})
+	if n > maxCachedTemplates || tmplCount.Load() != int64(n) {
+		t.Fatalf("got %d cached templates, counted %d", n, tmplCount.Load())
+	}
+}
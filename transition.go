@@ -0,0 +1,33 @@
+package result
+
+// SetAllowedTransitions sets the statuses that each status can move to by Return.
+// Statuses without an entry can move to any status, and an empty entry blocks
+// all transitions from that status. A nil map removes the restrictions.
+func (r *Result) SetAllowedTransitions(m map[Status][]Status) {
+	if m == nil {
+		r.transitions = nil
+		return
+	}
+	r.transitions = make(map[Status][]Status, len(m))
+	for k, v := range m {
+		r.transitions[k] = append([]Status(nil), v...)
+	}
+}
+
+// canTransition checks if the status can move to the next status
+func (r *Result) canTransition(next Status) bool {
+	cur := Status(r.Status)
+	if r.transitions == nil || cur == next {
+		return true
+	}
+	allowed, ok := r.transitions[cur]
+	if !ok {
+		return true
+	}
+	for _, s := range allowed {
+		if s == next {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,95 @@
+package result
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTransitions(t *testing.T) {
+	forward := map[Status][]Status{
+		OK:        {EXCEPTION},
+		EXCEPTION: {},
+	}
+	tests := []struct {
+		name   string
+		strict bool
+		run    func(r *Result)
+		status Status
+		msgs   []string
+	}{
+		{"allowed", false, func(r *Result) { r.Return(EXCEPTION) }, EXCEPTION, []string{}},
+		{"blocked warns", false, func(r *Result) {
+			r.Return(EXCEPTION)
+			r.Return(OK)
+		}, OK, []string{"WRN: Status transition from EXCEPTION to OK is not allowed"}},
+		{"blocked strict", true, func(r *Result) {
+			r.Return(EXCEPTION)
+			r.Return(OK)
+		}, EXCEPTION, []string{"ERR: Status transition from EXCEPTION to OK is not allowed"}},
+		{"same status", true, func(r *Result) { r.Return(OK) }, OK, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := InitResult(WithStatus(OK), WithStrictTransitions(tt.strict))
+			r.SetAllowedTransitions(forward)
+			tt.run(&r)
+			if r.Status != string(tt.status) || !reflect.DeepEqual(r.Messages, tt.msgs) {
+				t.Fatalf("got %s %q, want %s %q", r.Status, r.Messages, tt.status, tt.msgs)
+			}
+		})
+	}
+}
+
+func TestRaisedStatusHonoursStrictTransitions(t *testing.T) {
+	blocked := map[Status][]Status{OK: {}}
+	tests := []struct {
+		name string
+		run  func(r *Result)
+		want []string
+	}{
+		{"escalating warning", func(r *Result) {
+			r.AddEscalatingWarning("k", "slow")
+			r.AddEscalatingWarning("k", "slow")
+		}, []string{
+			"WRN: slow",
+			"ERR: Status transition from OK to EXCEPTION is not allowed",
+			"ERR: slow",
+		}},
+		{"promote warnings", func(r *Result) {
+			r.AddWarning("slow")
+			r.PromoteWarningsToErrors()
+		}, []string{
+			"ERR: slow",
+			"ERR: Status transition from OK to EXCEPTION is not allowed",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := InitResult(WithStatus(OK), WithStrictTransitions(true), WithEscalationThreshold(1))
+			r.SetAllowedTransitions(blocked)
+			tt.run(&r)
+			if r.Status != string(OK) {
+				t.Fatalf("got status %s", r.Status)
+			}
+			if !reflect.DeepEqual(r.Messages, tt.want) {
+				t.Fatalf("got %q, want %q", r.Messages, tt.want)
+			}
+		})
+	}
+}
+
+func TestPromoteWarningsToErrors(t *testing.T) {
+	r := InitResult(WithStatus(OK))
+	r.AddInfo("i")
+	r.AddWarning("w1")
+	r.AddError("e")
+	r.AddWarning("w2")
+	r.PromoteWarningsToErrors()
+	errs, warns, infos := r.Partition()
+	if len(errs) != 3 || len(warns) != 0 || len(infos) != 1 {
+		t.Fatalf("got %d errors, %d warnings, %d infos", len(errs), len(warns), len(infos))
+	}
+	if r.Status != string(EXCEPTION) {
+		t.Fatalf("got status %s", r.Status)
+	}
+}
@@ -0,0 +1,107 @@
+package result
+
+import (
+	"fmt"
+	"net/mail"
+	"reflect"
+	"strings"
+	"unicode/utf8"
+
+	l "github.com/stdutil/log"
+)
+
+// Validator accumulates field errors into a Result
+type Validator struct {
+	res *Result
+}
+
+// AddFieldError adds a formatted error message for a field and returns itself.
+// The first field error sets the focus control to the field, formatted by the
+// formatter set by WithFocusControlFormatter.
+func (r *Result) AddFieldError(field, fmtMsg string, a ...any) Result {
+	first := true
+	for _, m := range r.nmeta {
+		if m.field != "" {
+			first = false
+			break
+		}
+	}
+	n := len(r.ln.Notes())
+	r.add(l.Error, fmtMsg, a...)
+	if len(r.ln.Notes()) == n {
+		return *r
+	}
+	r.nmeta[len(r.nmeta)-1].field = field
+	if first {
+		fc := field
+		if r.fcFormatter != nil {
+			fc = r.fcFormatter(field)
+		}
+		r.FocusControl = &fc
+	}
+	return *r
+}
+
+// NewValidator creates a Validator that adds field errors to the Result
+func (r *Result) NewValidator() *Validator {
+	return &Validator{res: r}
+}
+
+// Required fails if the value is nil, a blank string, or an empty slice, map or array
+func (v *Validator) Required(field string, val any) *Validator {
+	return v.Custom(field, !isEmpty(val), fmt.Sprintf("%s is required", field))
+}
+
+// MinLen fails if a non-empty value has less than n characters
+func (v *Validator) MinLen(field, val string, n int) *Validator {
+	ok := val == "" || utf8.RuneCountInString(val) >= n
+	return v.Custom(field, ok, fmt.Sprintf("%s must be at least %d characters", field, n))
+}
+
+// MaxLen fails if the value has more than n characters
+func (v *Validator) MaxLen(field, val string, n int) *Validator {
+	ok := utf8.RuneCountInString(val) <= n
+	return v.Custom(field, ok, fmt.Sprintf("%s must be at most %d characters", field, n))
+}
+
+// Email fails if a non-empty value is not a valid email address
+func (v *Validator) Email(field, val string) *Validator {
+	ok := val == ""
+	if !ok {
+		addr, err := mail.ParseAddress(val)
+		ok = err == nil && addr.Address == val
+	}
+	return v.Custom(field, ok, fmt.Sprintf("%s must be a valid email address", field))
+}
+
+// Custom adds a field error with the message and sets the status to INVALID if ok is false
+func (v *Validator) Custom(field string, ok bool, msg string) *Validator {
+	if ok {
+		return v
+	}
+	v.res.AddFieldError(field, "%s", msg)
+	v.res.Return(INVALID)
+	return v
+}
+
+// Done returns the Result, with the focus control set to the first failed field
+func (v *Validator) Done() Result {
+	return *v.res
+}
+
+// isEmpty checks if a value is nil, a blank string, or an empty slice, map or array
+func isEmpty(val any) bool {
+	if val == nil {
+		return true
+	}
+	rv := reflect.ValueOf(val)
+	switch rv.Kind() {
+	case reflect.String:
+		return strings.TrimSpace(rv.String()) == ""
+	case reflect.Slice, reflect.Map, reflect.Array, reflect.Chan:
+		return rv.Len() == 0
+	case reflect.Pointer, reflect.Interface:
+		return rv.IsNil()
+	}
+	return false
+}
@@ -0,0 +1,126 @@
+package result
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValidator(t *testing.T) {
+	type addr struct{ City string }
+	tests := []struct {
+		name     string
+		validate func(v *Validator)
+		want     []string
+		focus    string
+	}{
+		{"valid", func(v *Validator) {
+			v.Required("name", "ann").
+				Required("tags", []string{"a"}).
+				Required("addr", &addr{}).
+				MinLen("name", "ann", 3).
+				MaxLen("name", "ann", 3).
+				Email("email", "ann@example.com").
+				Email("backup", "")
+		}, []string{}, ""},
+		{"required", func(v *Validator) {
+			v.Required("name", "  ").
+				Required("tags", []string{}).
+				Required("addr", (*addr)(nil)).
+				Required("any", nil)
+		}, []string{"ERR: name is required", "ERR: tags is required", "ERR: addr is required", "ERR: any is required"}, "name"},
+		{"length in runes", func(v *Validator) {
+			v.MinLen("code", "ñé", 3).
+				MinLen("optional", "", 3).
+				MaxLen("title", "ñéñ", 2)
+		}, []string{"ERR: code must be at least 3 characters", "ERR: title must be at most 2 characters"}, "code"},
+		{"email", func(v *Validator) {
+			v.Email("email", "Ann <ann@example.com>").
+				Email("other", "not-an-email")
+		}, []string{"ERR: email must be a valid email address", "ERR: other must be a valid email address"}, "email"},
+		{"custom", func(v *Validator) {
+			v.Custom("age", true, "ignored").
+				Custom("age", false, "age must be positive")
+		}, []string{"ERR: age must be positive"}, "age"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := InitResult(WithStatus(OK))
+			v := r.NewValidator()
+			tt.validate(v)
+			got := v.Done()
+			if !reflect.DeepEqual(got.Messages, tt.want) {
+				t.Fatalf("got %q, want %q", got.Messages, tt.want)
+			}
+			wantStatus := OK
+			if len(tt.want) > 0 {
+				wantStatus = INVALID
+			}
+			if got.Status != string(wantStatus) {
+				t.Fatalf("got status %s", got.Status)
+			}
+			focus := ""
+			if got.FocusControl != nil {
+				focus = *got.FocusControl
+			}
+			if focus != tt.focus {
+				t.Fatalf("got focus %q, want %q", focus, tt.focus)
+			}
+		})
+	}
+}
+
+func TestAddFieldError(t *testing.T) {
+	r := InitResult(WithStatus(INVALID))
+	r.AddError("not a field error")
+	r.AddFieldError("email", "%s is taken", "ann@example.com")
+	r.AddFieldError("name", "name is required")
+	if *r.FocusControl != "email" {
+		t.Fatalf("got focus %q", *r.FocusControl)
+	}
+	var fields []string
+	for _, m := range r.StructuredMessages() {
+		fields = append(fields, m.Field)
+	}
+	if want := []string{"", "email", "name"}; !reflect.DeepEqual(fields, want) {
+		t.Fatalf("got fields %q, want %q", fields, want)
+	}
+}
+
+func TestFocusControlFormatter(t *testing.T) {
+	kebab := func(field string) string {
+		var b []rune
+		for i, c := range field {
+			if c >= 'A' && c <= 'Z' {
+				if i > 0 {
+					b = append(b, '-')
+				}
+				c += 'a' - 'A'
+			}
+			b = append(b, c)
+		}
+		return string(b)
+	}
+	tests := []struct {
+		name string
+		opts []InitResultOption
+		want string
+	}{
+		{"as is", nil, "billingAddress"},
+		{"kebab case", []InitResultOption{WithFocusControlFormatter(kebab)}, "billing-address"},
+		{"prefix", []InitResultOption{WithFocusControlFormatter(func(f string) string { return "#" + f })}, "#billingAddress"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := InitResult(append([]InitResultOption{WithStatus(OK)}, tt.opts...)...)
+			r.NewValidator().
+				Required("billingAddress", "").
+				Required("zipCode", "")
+			if *r.FocusControl != tt.want {
+				t.Fatalf("got focus %q, want %q", *r.FocusControl, tt.want)
+			}
+			if got := r.StructuredMessages()[0].Field; got != "billingAddress" {
+				t.Fatalf("got field %q", got)
+			}
+		})
+	}
+}